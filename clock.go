@@ -0,0 +1,18 @@
+package ar
+
+import "time"
+
+type Clock func() time.Time
+
+var clock Clock = time.Now
+
+func SetClock(c Clock) {
+	if c == nil {
+		c = time.Now
+	}
+	clock = c
+}
+
+func Now() time.Time {
+	return clock().UTC()
+}
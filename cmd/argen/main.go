@@ -29,6 +29,7 @@ func main() {
 	err = gen.Generate(from, opts)
 	if err != nil {
 		fmt.Printf("%v\n", err)
+		os.Exit(1)
 	}
 
 }
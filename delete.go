@@ -1,6 +1,7 @@
 package ar
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/monochromegane/argen/query"
@@ -12,27 +13,79 @@ type Delete struct {
 }
 
 func NewDelete(db *sql.DB, logger *Logger) *Delete {
+	return newDelete(db, logger)
+}
+
+func NewDeleteTx(tx *Tx, logger *Logger) *Delete {
+	return newDelete(tx, logger)
+}
+
+func newDelete(conn Conn, logger *Logger) *Delete {
 	return &Delete{
 		Delete: &query.Delete{},
-		exec:   &Executer{db, logger},
+		exec:   &Executer{conn: conn, logger: logger},
 	}
 }
 
+func (d *Delete) Dialect(dialect query.Dialect) *Delete {
+	d.Delete.Dialect(dialect)
+	return d
+}
+
+func (d *Delete) Cache(c *StmtCache) *Delete {
+	d.exec.cache = c
+	return d
+}
+
 func (d *Delete) Table(table string) *Delete {
 	d.Delete.Table(table)
 	return d
 }
 
-func (d *Delete) Where(cond string, args ...interface{}) *Delete {
+func (d *Delete) Where(cond interface{}, args ...interface{}) *Delete {
 	d.Delete.Where(cond, args...)
 	return d
 }
 
-func (d *Delete) And(cond string, args ...interface{}) *Delete {
+func (d *Delete) And(cond interface{}, args ...interface{}) *Delete {
 	return d.Where(cond, args...)
 }
 
+func (d *Delete) Or(cond interface{}, args ...interface{}) *Delete {
+	d.Delete.Or(cond, args...)
+	return d
+}
+
+func (d *Delete) Not(cond interface{}, args ...interface{}) *Delete {
+	d.Delete.Not(cond, args...)
+	return d
+}
+
+func (d *Delete) Returning(columns ...string) *Delete {
+	d.Delete.Returning(columns...)
+	return d
+}
+
 func (d *Delete) Exec() (sql.Result, error) {
+	return d.ExecContext(context.Background())
+}
+
+func (d *Delete) ExecContext(ctx context.Context) (sql.Result, error) {
+	if err := d.ConditionError(); err != nil {
+		return nil, err
+	}
+	q, b := d.Delete.Build()
+	return d.exec.ExecContext(ctx, q, b...)
+}
+
+func (d *Delete) Query() (*sql.Rows, error) {
+	return d.QueryContext(context.Background())
+}
+
+func (d *Delete) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	if err := d.ConditionError(); err != nil {
+		return nil, err
+	}
 	q, b := d.Delete.Build()
-	return d.exec.Exec(q, b...)
+	return d.exec.QueryContext(ctx, q, b...)
 }
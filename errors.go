@@ -1,6 +1,11 @@
 package ar
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrStaleObject = errors.New("stale object")
 
 type Errors struct {
 	Messages map[string][]error
@@ -21,6 +26,17 @@ func (e *Errors) Add(field string, err string) {
 	msgs[field] = append(msgs[field], fmt.Errorf(err))
 }
 
+func (e *Errors) Is(target error) bool {
+	for _, errs := range e.message() {
+		for _, err := range errs {
+			if err == target {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (e *Errors) message() map[string][]error {
 	if e.Messages == nil {
 		e.Messages = map[string][]error{}
@@ -1,18 +1,78 @@
 package ar
 
 import (
+	"context"
 	"database/sql"
 	"time"
 )
 
+type Conn interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
 type Executer struct {
-	db     *sql.DB
+	conn   Conn
 	logger *Logger
+	cache  *StmtCache
 }
 
 func (e *Executer) Exec(q string, b ...interface{}) (sql.Result, error) {
+	return e.ExecContext(context.Background(), q, b...)
+}
+
+func (e *Executer) ExecContext(ctx context.Context, q string, b ...interface{}) (sql.Result, error) {
+	defer e.log(time.Now(), q, b...)
+	if e.cache == nil {
+		return e.conn.ExecContext(ctx, q, b...)
+	}
+	stmt, release, err := e.cache.stmt(ctx, e.conn, q)
+	if err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return e.conn.ExecContext(ctx, q, b...)
+	}
+	defer release()
+	return stmt.ExecContext(ctx, b...)
+}
+
+func (e *Executer) Query(q string, b ...interface{}) (*sql.Rows, error) {
+	return e.QueryContext(context.Background(), q, b...)
+}
+
+func (e *Executer) QueryContext(ctx context.Context, q string, b ...interface{}) (*sql.Rows, error) {
+	defer e.log(time.Now(), q, b...)
+	if e.cache == nil {
+		return e.conn.QueryContext(ctx, q, b...)
+	}
+	stmt, release, err := e.cache.stmt(ctx, e.conn, q)
+	if err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return e.conn.QueryContext(ctx, q, b...)
+	}
+	defer release()
+	return stmt.QueryContext(ctx, b...)
+}
+
+func (e *Executer) QueryRow(q string, b ...interface{}) *sql.Row {
+	return e.QueryRowContext(context.Background(), q, b...)
+}
+
+func (e *Executer) QueryRowContext(ctx context.Context, q string, b ...interface{}) *sql.Row {
 	defer e.log(time.Now(), q, b...)
-	return e.db.Exec(q, b...)
+	if e.cache == nil {
+		return e.conn.QueryRowContext(ctx, q, b...)
+	}
+	stmt, release, err := e.cache.stmt(ctx, e.conn, q)
+	if err != nil || stmt == nil {
+		return e.conn.QueryRowContext(ctx, q, b...)
+	}
+	defer release()
+	return stmt.QueryRowContext(ctx, b...)
 }
 
 func (e *Executer) log(t time.Time, sql string, args ...interface{}) {
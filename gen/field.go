@@ -1,5 +1,7 @@
 package gen
 
+import "fmt"
+
 type field struct {
 	Type string
 	Name string
@@ -16,3 +18,49 @@ func (f field) isPrimaryKey() bool {
 	}
 	return false
 }
+
+func (f field) IsLockVersion() bool {
+	return f.Tag.get("db") == "lock_version"
+}
+
+func (f field) IsTime() bool {
+	return f.Type == "Time" || f.Type == "*Time"
+}
+
+func (f field) IsCreatedAt() bool {
+	return f.isTimestamp("CreatedAt", "created_at")
+}
+
+func (f field) IsUpdatedAt() bool {
+	return f.isTimestamp("UpdatedAt", "updated_at")
+}
+
+func (f field) IsDeletedAt() bool {
+	if t := f.Tag.get("db"); t != "" {
+		return t == "deleted_at"
+	}
+	return f.Name == "DeletedAt" && f.Type == "*Time"
+}
+
+func (f field) validate() error {
+	var typ, name string
+	switch {
+	case f.IsCreatedAt(), f.IsUpdatedAt():
+		typ, name = "Time", "time.Time"
+	case f.IsDeletedAt():
+		typ, name = "*Time", "*time.Time"
+	default:
+		return nil
+	}
+	if f.Type != typ {
+		return fmt.Errorf("%s should be %s to be a timestamp, but %s", f.Name, name, f.Type)
+	}
+	return nil
+}
+
+func (f field) isTimestamp(name, tag string) bool {
+	if t := f.Tag.get("db"); t != "" {
+		return t == tag
+	}
+	return f.Name == name && f.Type == "Time"
+}
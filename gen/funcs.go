@@ -49,6 +49,30 @@ func (f funcType) customValidation() bool {
 	return match
 }
 
+var callbacks = []string{
+	"beforeValidation",
+	"afterValidation",
+	"beforeSave",
+	"afterSave",
+	"beforeCreate",
+	"afterCreate",
+	"beforeUpdate",
+	"afterUpdate",
+	"beforeDestroy",
+	"afterDestroy",
+	"afterCommit",
+	"afterRollback",
+}
+
+func (f funcType) callback() bool {
+	for _, c := range callbacks {
+		if f.Name == c {
+			return true
+		}
+	}
+	return false
+}
+
 type HasOne struct {
 	Recv *structType
 	funcType
@@ -40,6 +40,9 @@ func parse(file string) (structs, error) {
 	funcs := StructFuncs(f)
 
 	for _, st := range structs {
+		if err := st.validate(); err != nil {
+			return nil, err
+		}
 		st.Funcs = funcs[st.Name]
 	}
 	return structs, nil
@@ -101,16 +101,28 @@ func findStruct(specs []ast.Spec) (*structType, bool) {
 
 		st.Name = t.Name.Name
 		for _, f := range s.Fields.List {
+			if len(f.Names) == 0 {
+				if sel, ok := f.Type.(*ast.SelectorExpr); ok && sel.Sel.Name == "Snapshot" {
+					st.HasSnapshot = true
+				}
+				continue
+			}
 			var ident *ast.Ident
-			switch f.Type.(type) {
+			var ptr string
+			typ := f.Type
+			if star, ok := typ.(*ast.StarExpr); ok {
+				ptr = "*"
+				typ = star.X
+			}
+			switch typ.(type) {
 			case *ast.Ident:
-				ident = f.Type.(*ast.Ident)
+				ident = typ.(*ast.Ident)
 			case *ast.SelectorExpr:
-				ident = f.Type.(*ast.SelectorExpr).Sel
+				ident = typ.(*ast.SelectorExpr).Sel
 			}
 			field := field{
 				Name: f.Names[0].Name,
-				Type: ident.Name,
+				Type: ptr + ident.Name,
 			}
 			if f.Tag != nil {
 				field.Tag = tag(f.Tag.Value)
@@ -126,7 +138,11 @@ func findRecv(recv *ast.FieldList) (string, bool) {
 		return "", false
 	}
 	for _, r := range recv.List {
-		f, ok := r.Type.(*ast.Ident)
+		typ := r.Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+		f, ok := typ.(*ast.Ident)
 		if !ok {
 			return "", false
 		}
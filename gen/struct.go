@@ -1,6 +1,7 @@
 package gen
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -14,11 +15,21 @@ func (ss structs) Package() string {
 }
 
 type structType struct {
-	Package  string
-	Comments comments
-	Name     string
-	Fields   []field
-	Funcs    funcs
+	Package     string
+	Comments    comments
+	Name        string
+	Fields      []field
+	Funcs       funcs
+	HasSnapshot bool
+}
+
+func (s structType) validate() error {
+	for _, f := range s.Fields {
+		if err := f.validate(); err != nil {
+			return fmt.Errorf("%s.%s", s.Name, err)
+		}
+	}
+	return nil
 }
 
 func (s structType) TableName() string {
@@ -49,6 +60,75 @@ func (s structType) primaryKey() (string, string, string) {
 	return "ID", "id", "int"
 }
 
+func (s structType) HasLockVersion() bool {
+	_, ok := s.fieldBy(field.IsLockVersion)
+	return ok
+}
+
+func (s structType) LockVersionField() string {
+	f, _ := s.fieldBy(field.IsLockVersion)
+	return f.Name
+}
+
+func (s structType) LockVersionColumn() string {
+	f, _ := s.fieldBy(field.IsLockVersion)
+	return f.ColumnName()
+}
+
+func (s structType) HasCreatedAt() bool {
+	_, ok := s.fieldBy(field.IsCreatedAt)
+	return ok
+}
+
+func (s structType) CreatedAtField() string {
+	f, _ := s.fieldBy(field.IsCreatedAt)
+	return f.Name
+}
+
+func (s structType) CreatedAtColumn() string {
+	f, _ := s.fieldBy(field.IsCreatedAt)
+	return f.ColumnName()
+}
+
+func (s structType) HasUpdatedAt() bool {
+	_, ok := s.fieldBy(field.IsUpdatedAt)
+	return ok
+}
+
+func (s structType) UpdatedAtField() string {
+	f, _ := s.fieldBy(field.IsUpdatedAt)
+	return f.Name
+}
+
+func (s structType) UpdatedAtColumn() string {
+	f, _ := s.fieldBy(field.IsUpdatedAt)
+	return f.ColumnName()
+}
+
+func (s structType) HasDeletedAt() bool {
+	_, ok := s.fieldBy(field.IsDeletedAt)
+	return ok
+}
+
+func (s structType) DeletedAtField() string {
+	f, _ := s.fieldBy(field.IsDeletedAt)
+	return f.Name
+}
+
+func (s structType) DeletedAtColumn() string {
+	f, _ := s.fieldBy(field.IsDeletedAt)
+	return f.ColumnName()
+}
+
+func (s structType) fieldBy(fn func(field) bool) (field, bool) {
+	for _, f := range s.Fields {
+		if fn(f) {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
 func (s structType) FieldsWithoutPrimaryKey() []field {
 	fields := []field{}
 	for _, f := range s.Fields {
@@ -129,6 +209,19 @@ func (s structType) CustomValidation() []Validation {
 	return validation
 }
 
+func (s structType) HasCallback(name string) bool {
+	for _, f := range s.Funcs {
+		if f.callback() && f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s structType) HasTransactionCallback() bool {
+	return s.HasCallback("afterCommit") || s.HasCallback("afterRollback")
+}
+
 func toSnakeCase(s string) string {
 	const snake = "${1}_${2}"
 	reg1 := regexp.MustCompile("([A-Z]+)([A-Z][a-z])")
@@ -25,6 +25,10 @@ var structTemplates = Templates{
 	fieldByName,
 	build,
 	create,
+	upsert,
+	insertAll,
+	updateAll,
+	increment,
 	save,
 	sel,
 	find,
@@ -34,6 +38,17 @@ var structTemplates = Templates{
 	queryRow,
 	where,
 	and,
+	or,
+	not,
+	whereExists,
+	from,
+	compound,
+	dirty,
+	timestamps,
+	softDelete,
+	unscope,
+	lock,
+	with,
 	first,
 	last,
 	order,
@@ -48,6 +63,7 @@ var structTemplates = Templates{
 	hasMany,
 	hasOne,
 	belongsTo,
+	joins,
 	joinsHasAny,
 	joinsBelongsTo,
 	buildHasAny,
@@ -55,17 +71,87 @@ var structTemplates = Templates{
 	delete,
 	destroy,
 	update,
+	transactionCallback,
 }
 
 var structDb = `// generated by argen; DO NOT EDIT
 package {{.Package}}
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+
+	"github.com/monochromegane/argen"
+	"github.com/monochromegane/argen/query"
+)
 
 var db *sql.DB
+var dialect query.Dialect
+var stmtCache *ar.StmtCache
+var strictLock bool
 
-func Use(DB *sql.DB) {
+func Use(DB *sql.DB, d ...query.Dialect) {
 	db = DB
+	if len(d) > 0 {
+		dialect = d[0]
+	}
+}
+
+func UseStmtCache(size int) {
+	if stmtCache != nil {
+		stmtCache.Close()
+	}
+	stmtCache = nil
+	if size > 0 {
+		stmtCache = ar.NewStmtCache(db, size)
+	}
+}
+
+func UseStrictLock(strict bool) {
+	strictLock = strict
+}
+
+func Transaction(fn func(tx *ar.Tx) error) error {
+	return ar.Transaction(db, logger, fn)
+}
+
+func TransactionContext(ctx context.Context, fn func(tx *ar.Tx) error) error {
+	return ar.TransactionContext(ctx, db, logger, fn)
+}
+
+func TransactionTx(tx *ar.Tx, fn func(tx *ar.Tx) error) error {
+	if tx != nil {
+		return tx.Transaction(fn)
+	}
+	return Transaction(fn)
+}
+
+func newRelation(tx *ar.Tx) *ar.Relation {
+	if tx != nil {
+		return ar.NewRelationTx(tx, logger).Dialect(dialect).Cache(stmtCache).StrictLock(strictLock)
+	}
+	return ar.NewRelation(db, logger).Dialect(dialect).Cache(stmtCache).StrictLock(strictLock)
+}
+
+func newInsert(tx *ar.Tx) *ar.Insert {
+	if tx != nil {
+		return ar.NewInsertTx(tx, logger).Dialect(dialect).Cache(stmtCache)
+	}
+	return ar.NewInsert(db, logger).Dialect(dialect).Cache(stmtCache)
+}
+
+func newUpdate(tx *ar.Tx) *ar.Update {
+	if tx != nil {
+		return ar.NewUpdateTx(tx, logger).Dialect(dialect).Cache(stmtCache)
+	}
+	return ar.NewUpdate(db, logger).Dialect(dialect).Cache(stmtCache)
+}
+
+func newDelete(tx *ar.Tx) *ar.Delete {
+	if tx != nil {
+		return ar.NewDeleteTx(tx, logger).Dialect(dialect).Cache(stmtCache)
+	}
+	return ar.NewDelete(db, logger).Dialect(dialect).Cache(stmtCache)
 }
 `
 
@@ -90,6 +176,7 @@ var structTemplate = `// generated by argen; DO NOT EDIT
 package {{.Package}}
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/monochromegane/argen"
@@ -99,18 +186,30 @@ import (
 {{range .}}
 {{template "Relation" .}}
 {{template "Select" .}}
+{{template "With" .}}
 {{template "Find" .}}
 {{template "FindBy" .}}
 {{template "First" .}}
 {{template "Last" .}}
 {{template "Where" .}}
 {{template "And" .}}
+{{template "Or" .}}
+{{template "Not" .}}
+{{template "WhereExists" .}}
+{{template "From" .}}
 {{template "Order" .}}
+{{template "Unscope" .}}
 {{template "Limit" .}}
 {{template "Offset" .}}
 {{template "Group" .}}
 {{template "Having" .}}
+{{template "Joins" .}}
+{{template "Compound" .}}
+{{template "Lock" .}}
 {{template "Validation" .}}
+{{template "Dirty" .}}
+{{template "Timestamps" .}}
+{{template "SoftDelete" .}}
 {{range .Scope}}
 {{template "Scope" .}}
 {{end}}
@@ -130,10 +229,17 @@ import (
 {{end}}
 {{template "Build" .}}
 {{template "Create" .}}
+{{template "Upsert" .}}
+{{template "InsertAll" .}}
 {{template "Save" .}}
 {{template "Update" .}}
+{{template "UpdateAll" .}}
+{{template "Increment" .}}
 {{template "Destroy" .}}
 {{template "Delete" .}}
+{{if .HasTransactionCallback}}
+{{template "TransactionCallback" .}}
+{{end}}
 {{template "Query" .}}
 {{template "QueryRow" .}}
 {{template "Exists" .}}
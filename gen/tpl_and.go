@@ -3,7 +3,7 @@ package gen
 var and = &Template{
 	Name: "And",
 	Text: `
-func (r *{{.Name}}Relation) And(cond string, args ...interface{}) *{{.Name}}Relation {
+func (r *{{.Name}}Relation) And(cond interface{}, args ...interface{}) *{{.Name}}Relation {
         r.Relation.And(cond, args...)
         return r
 }
@@ -4,6 +4,18 @@ var belongsTo = &Template{
 	Name: "BelongsTo",
 	Text: `
 func (m *{{.Recv.Name}}) {{.Func}}() (*{{.Model}}, error) {
+	return m.{{.FuncName}}Relation(nil).QueryRow()
+}
+
+func (m *{{.Recv.Name}}) {{.Func}}Tx(tx *ar.Tx) (*{{.Model}}, error) {
+	return m.{{.FuncName}}Relation(tx).QueryRow()
+}
+
+func (m *{{.Recv.Name}}) {{.Func}}Context(ctx context.Context) (*{{.Model}}, error) {
+	return m.{{.FuncName}}Relation(nil).QueryRowContext(ctx)
+}
+
+func (m *{{.Recv.Name}}) {{.FuncName}}Relation(tx *ar.Tx) *{{.Model}}Relation {
 	asc := m.{{.FuncName}}()
 	pk := "{{.PrimaryKey}}"
 	fk := "{{.ForeignKey}}"
@@ -13,6 +25,6 @@ func (m *{{.Recv.Name}}) {{.Func}}() (*{{.Model}}, error) {
 	if asc != nil && asc.ForeignKey != "" {
 		fk = asc.ForeignKey
 	}
-	return {{.Model}}{}.Where(pk, m.fieldValueByName(fk)).QueryRow()
+	return {{.Model}}{}.Tx(tx).Where(pk, m.fieldValueByName(fk))
 }
 `}
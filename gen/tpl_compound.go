@@ -0,0 +1,25 @@
+package gen
+
+var compound = &Template{
+	Name: "Compound",
+	Text: `
+func (r *{{.Name}}Relation) Union(query interface{}) *{{.Name}}Relation {
+        r.Relation.Union(query)
+        return r
+}
+
+func (r *{{.Name}}Relation) UnionAll(query interface{}) *{{.Name}}Relation {
+        r.Relation.UnionAll(query)
+        return r
+}
+
+func (r *{{.Name}}Relation) Intersect(query interface{}) *{{.Name}}Relation {
+        r.Relation.Intersect(query)
+        return r
+}
+
+func (r *{{.Name}}Relation) Except(query interface{}) *{{.Name}}Relation {
+        r.Relation.Except(query)
+        return r
+}
+`}
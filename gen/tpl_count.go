@@ -6,4 +6,8 @@ var count = &Template{
 func (m {{.Name}}) Count(column ...string) int {
 	return m.newRelation().Count(column...)
 }
+
+func (m {{.Name}}) CountContext(ctx context.Context, column ...string) int {
+	return m.newRelation().CountContext(ctx, column...)
+}
 `}
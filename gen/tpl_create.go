@@ -4,8 +4,20 @@ var create = &Template{
 	Name: "Create",
 	Text: `
 func (m {{.Name}}) Create(p {{.Name}}Params) (*{{.Name}}, *ar.Errors) {
+	return m.create(context.Background(), nil, p)
+}
+
+func (m {{.Name}}) CreateTx(tx *ar.Tx, p {{.Name}}Params) (*{{.Name}}, *ar.Errors) {
+	return m.create(tx.Context(), tx, p)
+}
+
+func (m {{.Name}}) CreateContext(ctx context.Context, p {{.Name}}Params) (*{{.Name}}, *ar.Errors) {
+	return m.create(ctx, nil, p)
+}
+
+func (m {{.Name}}) create(ctx context.Context, tx *ar.Tx, p {{.Name}}Params) (*{{.Name}}, *ar.Errors) {
 	n := m.Build(p)
-        _, errs := n.Save()
+        _, errs := n.save(ctx, tx)
         return n, errs
 }
 `}
@@ -4,20 +4,51 @@ var delete = &Template{
 	Name: "Delete",
 	Text: `
 func (m *{{.Name}}) Delete() (bool, *ar.Errors) {
-        errs := &ar.Errors{}
-        if _, err := ar.NewDelete(db, logger).Table("{{.TableName}}").Where("{{.PrimaryKeyColumn}}", m.{{.PrimaryKeyField}}).Exec(); err != nil {
-                errs.AddError("base", err)
-                return false, errs
-        }
-        return true, nil
+	return m.delete(context.Background(), nil, false)
+}
+
+func (m *{{.Name}}) DeleteTx(tx *ar.Tx) (bool, *ar.Errors) {
+	return m.delete(tx.Context(), tx, false)
 }
 
-func (m {{.Name}}) DeleteAll() (bool, *ar.Errors) {
+func (m *{{.Name}}) DeleteContext(ctx context.Context) (bool, *ar.Errors) {
+	return m.delete(ctx, nil, false)
+}
+
+func (m *{{.Name}}) delete(ctx context.Context, tx *ar.Tx, really bool) (bool, *ar.Errors) {
         errs := &ar.Errors{}
-        if _, err := ar.NewDelete(db, logger).Table("{{.TableName}}").Exec(); err != nil {
+	{{if .HasCallback "beforeDestroy"}}
+	if err := m.beforeDestroy(); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}{{end}}
+	{{if .HasDeletedAt}}if !really {
+		now := ar.Now()
+		upd := newUpdate(tx).Table("{{.TableName}}").Params(map[string]interface{}{"{{.DeletedAtColumn}}": now}).Where("{{.PrimaryKeyColumn}}", m.{{.PrimaryKeyField}})
+		if _, err := upd.ExecContext(ctx); err != nil {
+			errs.AddError("base", err)
+			return false, errs
+		}
+		m.{{.DeletedAtField}} = &now
+		m.takeSnapshot("{{.DeletedAtColumn}}")
+	} else {{end}}if _, err := newDelete(tx).Table("{{.TableName}}").Where("{{.PrimaryKeyColumn}}", m.{{.PrimaryKeyField}}).ExecContext(ctx); err != nil {
                 errs.AddError("base", err)
                 return false, errs
         }
+	{{if .HasCallback "afterDestroy"}}
+	if err := m.afterDestroy(); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}{{end}}
+	{{if .HasTransactionCallback}}m.afterTransaction(tx){{end}}
         return true, nil
 }
+
+func (m {{.Name}}) DeleteAll() (int64, error) {
+	return m.newRelation().DeleteAll()
+}
+
+func (m {{.Name}}) DeleteAllContext(ctx context.Context) (int64, error) {
+	return m.newRelation().DeleteAllContext(ctx)
+}
 `}
@@ -4,6 +4,14 @@ var destroy = &Template{
 	Name: "Destroy",
 	Text: `
 func (m *{{.Name}}) Destroy() (bool, *ar.Errors) {
-	return m.Delete()
+	return m.delete(context.Background(), nil, false)
+}
+
+func (m *{{.Name}}) DestroyTx(tx *ar.Tx) (bool, *ar.Errors) {
+	return m.delete(tx.Context(), tx, false)
+}
+
+func (m *{{.Name}}) DestroyContext(ctx context.Context) (bool, *ar.Errors) {
+	return m.delete(ctx, nil, false)
 }
 `}
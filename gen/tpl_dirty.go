@@ -0,0 +1,58 @@
+package gen
+
+var dirty = &Template{
+	Name: "Dirty",
+	Text: `
+func (m *{{.Name}}) takeSnapshot(columns ...string) {
+{{if .HasSnapshot}}	if len(columns) == 0 {
+		columns = m.columnNames()
+	}
+	values := map[string]interface{}{}
+	for _, c := range columns {
+		values[c] = m.fieldValueByName(c)
+	}
+	m.Snapshot.TakeSnapshot(values)
+{{end}}}
+
+func (m *{{.Name}}) changedColumns() []string {
+	columns := []string{}
+	for _, c := range m.columnNames() {
+		if c == "{{.PrimaryKeyColumn}}" {
+			continue
+		}{{if .HasSnapshot}}
+		if _, changed := m.Snapshot.SnapshotChange(c, m.fieldValueByName(c)); !changed {
+			continue
+		}{{end}}
+		columns = append(columns, c)
+	}
+	return columns
+}
+{{if .HasSnapshot}}
+func (m *{{.Name}}) Changed() bool {
+	return len(m.Changes()) > 0
+}
+
+func (m *{{.Name}}) Changes() map[string]ar.Change {
+	changes := map[string]ar.Change{}
+	for _, c := range m.columnNames() {
+		if change, changed := m.Snapshot.SnapshotChange(c, m.fieldValueByName(c)); changed {
+			changes[c] = change
+		}
+	}
+	return changes
+}
+
+func (m *{{.Name}}) WasChanged(column string) bool {
+	if !m.isColumnName(column) {
+		return false
+	}
+	_, changed := m.Snapshot.SnapshotChange(column, m.fieldValueByName(column))
+	return changed
+}
+
+func (m *{{.Name}}) RestoreAttributes() {
+	for _, c := range m.columnNames() {
+		m.Snapshot.RestoreSnapshot(c, m.fieldPtrByName(c))
+	}
+}
+{{end}}`}
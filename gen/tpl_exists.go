@@ -6,4 +6,8 @@ var exists = &Template{
 func (m {{.Name}}) Exists() bool {
 	return m.newRelation().Exists()
 }
+
+func (m {{.Name}}) ExistsContext(ctx context.Context) bool {
+	return m.newRelation().ExistsContext(ctx)
+}
 `}
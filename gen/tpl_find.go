@@ -7,7 +7,15 @@ func (m {{.Name}}) Find(id {{.PrimaryKeyType}}) (*{{.Name}}, error) {
         return m.newRelation().Find(id)
 }
 
+func (m {{.Name}}) FindContext(ctx context.Context, id {{.PrimaryKeyType}}) (*{{.Name}}, error) {
+        return m.newRelation().FindContext(ctx, id)
+}
+
 func (r *{{.Name}}Relation) Find(id {{.PrimaryKeyType}}) (*{{.Name}}, error) {
-        return r.FindBy("{{.PrimaryKeyColumn}}", id)
+        return r.FindContext(context.Background(), id)
+}
+
+func (r *{{.Name}}Relation) FindContext(ctx context.Context, id {{.PrimaryKeyType}}) (*{{.Name}}, error) {
+        return r.FindByContext(ctx, "{{.PrimaryKeyColumn}}", id)
 }
 `}
@@ -7,7 +7,15 @@ func (m {{.Name}}) FindBy(cond string, args ...interface{}) (*{{.Name}}, error)
         return m.newRelation().FindBy(cond, args...)
 }
 
+func (m {{.Name}}) FindByContext(ctx context.Context, cond string, args ...interface{}) (*{{.Name}}, error) {
+        return m.newRelation().FindByContext(ctx, cond, args...)
+}
+
 func (r *{{.Name}}Relation) FindBy(cond string, args ...interface{}) (*{{.Name}}, error) {
-        return r.Where(cond, args...).Limit(1).QueryRow()
+        return r.FindByContext(context.Background(), cond, args...)
+}
+
+func (r *{{.Name}}Relation) FindByContext(ctx context.Context, cond string, args ...interface{}) (*{{.Name}}, error) {
+        return r.Clone().Where(cond, args...).Limit(1).QueryRowContext(ctx)
 }
 `}
@@ -7,7 +7,15 @@ func (m {{.Name}}) First() (*{{.Name}}, error) {
 	return m.newRelation().First()
 }
 
+func (m {{.Name}}) FirstContext(ctx context.Context) (*{{.Name}}, error) {
+	return m.newRelation().FirstContext(ctx)
+}
+
 func (r *{{.Name}}Relation) First() (*{{.Name}}, error) {
-        return r.Order("{{.PrimaryKeyColumn}}", "ASC").Limit(1).QueryRow()
+        return r.FirstContext(context.Background())
+}
+
+func (r *{{.Name}}Relation) FirstContext(ctx context.Context) (*{{.Name}}, error) {
+        return r.Clone().Order("{{.PrimaryKeyColumn}}", "ASC").Limit(1).QueryRowContext(ctx)
 }
 `}
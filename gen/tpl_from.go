@@ -0,0 +1,14 @@
+package gen
+
+var from = &Template{
+	Name: "From",
+	Text: `
+func (m {{.Name}}) From(source interface{}, alias string) *{{.Name}}Relation {
+        return m.newRelation().From(source, alias)
+}
+
+func (r *{{.Name}}Relation) From(source interface{}, alias string) *{{.Name}}Relation {
+        r.Relation.From(source, alias)
+        return r
+}
+`}
@@ -4,11 +4,23 @@ var hasOne = &Template{
 	Name: "HasOne",
 	Text: `
 func (m *{{.Recv.Name}}) {{.Func}}() (*{{.Model}}, error) {
+	return m.{{.FuncName}}Relation(nil).QueryRow()
+}
+
+func (m *{{.Recv.Name}}) {{.Func}}Tx(tx *ar.Tx) (*{{.Model}}, error) {
+	return m.{{.FuncName}}Relation(tx).QueryRow()
+}
+
+func (m *{{.Recv.Name}}) {{.Func}}Context(ctx context.Context) (*{{.Model}}, error) {
+	return m.{{.FuncName}}Relation(nil).QueryRowContext(ctx)
+}
+
+func (m *{{.Recv.Name}}) {{.FuncName}}Relation(tx *ar.Tx) *{{.Model}}Relation {
 	asc := m.{{.FuncName}}()
 	fk := "{{.ForeignKey}}"
 	if asc != nil && asc.ForeignKey != "" {
 		fk = asc.ForeignKey
 	}
-	return {{.Model}}{}.Where(fk, m.{{.Recv.PrimaryKeyField}}).QueryRow()
+	return {{.Model}}{}.Tx(tx).Where(fk, m.{{.Recv.PrimaryKeyField}})
 }
 `}
@@ -0,0 +1,103 @@
+package gen
+
+var increment = &Template{
+	Name: "Increment",
+	Text: `
+func (m *{{.Name}}) Increment(column string, by int) (bool, *ar.Errors) {
+	return m.IncrementContext(context.Background(), column, by)
+}
+
+func (m *{{.Name}}) IncrementTx(tx *ar.Tx, column string, by int) (bool, *ar.Errors) {
+	return m.updateExpressions(tx.Context(), tx, map[string]interface{}{
+		column: query.Increment(column, by),
+	})
+}
+
+func (m *{{.Name}}) IncrementContext(ctx context.Context, column string, by int) (bool, *ar.Errors) {
+	return m.updateExpressions(ctx, nil, map[string]interface{}{
+		column: query.Increment(column, by),
+	})
+}
+
+func (m *{{.Name}}) Decrement(column string, by int) (bool, *ar.Errors) {
+	return m.IncrementContext(context.Background(), column, -by)
+}
+
+func (m *{{.Name}}) DecrementTx(tx *ar.Tx, column string, by int) (bool, *ar.Errors) {
+	return m.IncrementTx(tx, column, -by)
+}
+
+func (m *{{.Name}}) DecrementContext(ctx context.Context, column string, by int) (bool, *ar.Errors) {
+	return m.IncrementContext(ctx, column, -by)
+}
+
+func (m *{{.Name}}) Touch(columns ...string) (bool, *ar.Errors) {
+	return m.TouchContext(context.Background(), columns...)
+}
+
+func (m *{{.Name}}) TouchTx(tx *ar.Tx, columns ...string) (bool, *ar.Errors) {
+	return m.touch(tx.Context(), tx, columns)
+}
+
+func (m *{{.Name}}) TouchContext(ctx context.Context, columns ...string) (bool, *ar.Errors) {
+	return m.touch(ctx, nil, columns)
+}
+
+func (m *{{.Name}}) touch(ctx context.Context, tx *ar.Tx, columns []string) (bool, *ar.Errors) {
+	for _, c := range columns {
+		if !m.isTimeColumn(c) {
+			errs := &ar.Errors{}
+			errs.Add(c, "is not a timestamp")
+			return false, errs
+		}
+	}
+	return m.updateExpressions(ctx, tx, m.touchParams(columns))
+}
+
+func (m *{{.Name}}) isTimeColumn(name string) bool {
+	switch name { {{range .Fields}}{{if .IsTime}}
+	case "{{.ColumnName}}":
+		return true{{end}}{{end}}
+	}
+	return false
+}
+
+func (m *{{.Name}}) touchParams(columns []string) map[string]interface{} {
+	now := ar.Now()
+	params := map[string]interface{}{}
+	for _, c := range columns {
+		params[c] = now
+	}
+	{{if .HasUpdatedAt}}params["{{.UpdatedAtColumn}}"] = now
+	{{end}}return params
+}
+
+func (m *{{.Name}}) updateExpressions(ctx context.Context, tx *ar.Tx, params map[string]interface{}) (bool, *ar.Errors) {
+	errs := &ar.Errors{}
+	if len(params) == 0 {
+		return true, nil
+	}
+	columns := []string{}
+	for c := range params {
+		if !m.isColumnName(c) {
+			errs.Add(c, "is not a column")
+			return false, errs
+		}
+		columns = append(columns, c)
+	}
+
+	upd := newUpdate(tx).Table("{{.TableName}}").Params(params).Where("{{.PrimaryKeyColumn}}", m.{{.PrimaryKeyField}})
+	if _, err := upd.ExecContext(ctx); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}
+
+	rel := newRelation(tx).Table("{{.TableName}}").Columns(columns...).Where("{{.PrimaryKeyColumn}}", m.{{.PrimaryKeyField}})
+	if err := rel.QueryRowContext(ctx, m.fieldPtrsByName(columns)...); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}
+	m.takeSnapshot(columns...)
+	return true, nil
+}
+`}
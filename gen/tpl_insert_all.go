@@ -0,0 +1,56 @@
+package gen
+
+var insertAll = &Template{
+	Name: "InsertAll",
+	Text: `
+func (m {{.Name}}) InsertAll(ps []{{.Name}}Params, validate ...bool) (int64, *ar.Errors) {
+	return m.insertAll(context.Background(), nil, ps, validate...)
+}
+
+func (m {{.Name}}) InsertAllTx(tx *ar.Tx, ps []{{.Name}}Params, validate ...bool) (int64, *ar.Errors) {
+	return m.insertAll(tx.Context(), tx, ps, validate...)
+}
+
+func (m {{.Name}}) InsertAllContext(ctx context.Context, ps []{{.Name}}Params, validate ...bool) (int64, *ar.Errors) {
+	return m.insertAll(ctx, nil, ps, validate...)
+}
+
+func (m {{.Name}}) insertAll(ctx context.Context, tx *ar.Tx, ps []{{.Name}}Params, validate ...bool) (int64, *ar.Errors) {
+	if len(ps) == 0 {
+		return 0, nil
+	}
+	rows := make([]map[string]interface{}, len(ps))
+	for i, p := range ps {
+		n := m.Build(p)
+		n.setCreateTimestamps()
+		if len(validate) == 0 || len(validate) > 0 && validate[0] {
+			if ok, errs := n.IsValid(); !ok {
+				return 0, errs
+			}
+		}
+		rows[i] = map[string]interface{}{ {{range .FieldsWithoutPrimaryKey}}
+			"{{.ColumnName}}": n.{{.Name}},{{end}}
+		}
+	}
+
+	var affected int64
+	fn := func(tx *ar.Tx) error {
+		var err error
+		affected, err = newInsert(tx).Table("{{.TableName}}").Rows(rows...).ExecAllContext(ctx)
+		return err
+	}
+
+	var err error
+	if tx != nil {
+		err = tx.Transaction(fn)
+	} else {
+		err = TransactionContext(ctx, fn)
+	}
+	if err != nil {
+		errs := &ar.Errors{}
+		errs.AddError("base", err)
+		return 0, errs
+	}
+	return affected, nil
+}
+`}
@@ -0,0 +1,14 @@
+package gen
+
+var joins = &Template{
+	Name: "Joins",
+	Text: `
+func (m {{.Name}}) Joins(sql string, args ...interface{}) *{{.Name}}Relation {
+	return m.newRelation().Joins(sql, args...)
+}
+
+func (r *{{.Name}}Relation) Joins(sql string, args ...interface{}) *{{.Name}}Relation {
+        r.Relation.Joins(sql, args...)
+        return r
+}
+`}
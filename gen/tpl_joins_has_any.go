@@ -8,12 +8,25 @@ func (m {{.Recv.Name}}) Joins{{.Func}}() *{{.Recv.Name}}Relation {
 }
 
 func (r *{{.Recv.Name}}Relation) Joins{{.Func}}() *{{.Recv.Name}}Relation {
+	r.Relation.InnerJoin("{{.TableName}}", r.{{.FuncName}}On())
+        return r
+}
+
+func (m {{.Recv.Name}}) LeftJoins{{.Func}}() *{{.Recv.Name}}Relation {
+        return m.newRelation().LeftJoins{{.Func}}()
+}
+
+func (r *{{.Recv.Name}}Relation) LeftJoins{{.Func}}() *{{.Recv.Name}}Relation {
+	r.Relation.LeftJoin("{{.TableName}}", r.{{.FuncName}}On())
+        return r
+}
+
+func (r *{{.Recv.Name}}Relation) {{.FuncName}}On() string {
 	asc := r.src.{{.FuncName}}()
 	fk := "{{.ForeignKey}}"
 	if asc != nil && asc.ForeignKey != "" {
 		fk = asc.ForeignKey
 	}
-	r.Relation.InnerJoin("{{.TableName}}", fmt.Sprintf("{{.TableName}}.%s = {{.Recv.TableName}}.{{.Recv.PrimaryKeyColumn}}", fk))
-        return r
+	return fmt.Sprintf("{{.TableName}}.%s = {{.Recv.TableName}}.{{.Recv.PrimaryKeyColumn}}", fk)
 }
 `}
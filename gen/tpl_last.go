@@ -7,7 +7,15 @@ func (m {{.Name}}) Last() (*{{.Name}}, error) {
         return m.newRelation().Last()
 }
 
+func (m {{.Name}}) LastContext(ctx context.Context) (*{{.Name}}, error) {
+        return m.newRelation().LastContext(ctx)
+}
+
 func (r *{{.Name}}Relation) Last() (*{{.Name}}, error) {
-        return r.Order("{{.PrimaryKeyColumn}}", "DESC").Limit(1).QueryRow()
+        return r.LastContext(context.Background())
+}
+
+func (r *{{.Name}}Relation) LastContext(ctx context.Context) (*{{.Name}}, error) {
+        return r.Clone().Order("{{.PrimaryKeyColumn}}", "DESC").Limit(1).QueryRowContext(ctx)
 }
 `}
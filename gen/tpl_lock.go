@@ -0,0 +1,38 @@
+package gen
+
+var lock = &Template{
+	Name: "Lock",
+	Text: `
+func (m {{.Name}}) Lock(mode query.LockMode) *{{.Name}}Relation {
+	return m.newRelation().Lock(mode)
+}
+
+func (r *{{.Name}}Relation) Lock(mode query.LockMode) *{{.Name}}Relation {
+	r.Relation.Lock(mode)
+	return r
+}
+
+func (m *{{.Name}}) Reload(lock ...query.LockMode) error {
+	return m.ReloadContext(context.Background(), lock...)
+}
+
+func (m *{{.Name}}) ReloadTx(tx *ar.Tx, lock ...query.LockMode) error {
+	return m.reload(tx.Context(), tx, lock)
+}
+
+func (m *{{.Name}}) ReloadContext(ctx context.Context, lock ...query.LockMode) error {
+	return m.reload(ctx, nil, lock)
+}
+
+func (m *{{.Name}}) reload(ctx context.Context, tx *ar.Tx, lock []query.LockMode) error {
+	r := m.newRelationTx(tx).Where("{{.PrimaryKeyColumn}}", m.{{.PrimaryKeyField}})
+	if len(lock) > 0 {
+		r.Lock(lock[0])
+	}
+	if err := r.Relation.QueryRowContext(ctx, m.fieldPtrsByName(r.Relation.GetColumns())...); err != nil {
+		return err
+	}
+	m.takeSnapshot()
+	return nil
+}
+`}
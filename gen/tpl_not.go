@@ -0,0 +1,14 @@
+package gen
+
+var not = &Template{
+	Name: "Not",
+	Text: `
+func (m {{.Name}}) Not(cond interface{}, args ...interface{}) *{{.Name}}Relation {
+        return m.newRelation().Not(cond, args...)
+}
+
+func (r *{{.Name}}Relation) Not(cond interface{}, args ...interface{}) *{{.Name}}Relation {
+        r.Relation.Not(cond, args...)
+        return r
+}
+`}
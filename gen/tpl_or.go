@@ -0,0 +1,10 @@
+package gen
+
+var or = &Template{
+	Name: "Or",
+	Text: `
+func (r *{{.Name}}Relation) Or(cond interface{}, args ...interface{}) *{{.Name}}Relation {
+        r.Relation.Or(cond, args...)
+        return r
+}
+`}
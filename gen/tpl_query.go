@@ -4,7 +4,11 @@ var query = &Template{
 	Name: "Query",
 	Text: `
 func (r *{{.Name}}Relation) Query() ([]*{{.Name}}, error) {
-	rows, err := r.Relation.Query()
+	return r.QueryContext(context.Background())
+}
+
+func (r *{{.Name}}Relation) QueryContext(ctx context.Context) ([]*{{.Name}}, error) {
+	rows, err := r.Relation.QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -17,6 +21,7 @@ func (r *{{.Name}}Relation) Query() ([]*{{.Name}}, error) {
                 if err != nil {
                         return nil, err
                 }
+                row.takeSnapshot()
                 results = append(results, row)
         }
         return results, nil
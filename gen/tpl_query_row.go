@@ -4,11 +4,16 @@ var queryRow = &Template{
 	Name: "QueryRow",
 	Text: `
 func (r *{{.Name}}Relation) QueryRow() (*{{.Name}}, error) {
+	return r.QueryRowContext(context.Background())
+}
+
+func (r *{{.Name}}Relation) QueryRowContext(ctx context.Context) (*{{.Name}}, error) {
 	row := &{{.Name}}{}
-	err := r.Relation.QueryRow(row.fieldPtrsByName(r.Relation.GetColumns())...)
+	err := r.Relation.QueryRowContext(ctx, row.fieldPtrsByName(r.Relation.GetColumns())...)
 	if err != nil {
 		return nil, err
 	}
+	row.takeSnapshot()
 	return row, nil
 }
 `}
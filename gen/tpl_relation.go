@@ -9,14 +9,33 @@ type {{.Name}}Relation struct {
 }
 
 func (m *{{.Name}}) newRelation() *{{.Name}}Relation {
+	return m.newRelationTx(nil)
+}
+
+func (m *{{.Name}}) newRelationTx(tx *ar.Tx) *{{.Name}}Relation {
+	r := m.unscopedRelationTx(tx)
+	{{if .DefaultScope}}m.defaultScope(ar.Scope{r.Relation, nil})
+	r.Relation.ScopeWhere(){{end}}
+	{{if .HasDeletedAt}}r.Relation.Scope("{{.TableName}}.{{.DeletedAtColumn}}", nil){{end}}
+	return r
+}
+
+func (m *{{.Name}}) unscopedRelationTx(tx *ar.Tx) *{{.Name}}Relation {
 	r := &{{.Name}}Relation{
 		m,
-		ar.NewRelation(db, logger).Table("{{.TableName}}"),
+		newRelation(tx).Table("{{.TableName}}").PrimaryKey("{{.PrimaryKeyColumn}}"),
 	}
 	r.Select({{range .Fields}}
 		"{{.ColumnName}}",{{end}}
 	)
-	{{if .DefaultScope}}m.defaultScope(ar.Scope{r.Relation, nil}){{end}}
 	return r
 }
+
+func (r *{{.Name}}Relation) Clone() *{{.Name}}Relation {
+	return &{{.Name}}Relation{r.src, r.Relation.Clone()}
+}
+
+func (m {{.Name}}) Tx(tx *ar.Tx) *{{.Name}}Relation {
+	return m.newRelationTx(tx)
+}
 `}
@@ -12,36 +12,93 @@ func (m *{{.Name}}) IsPersistent() bool {
 }
 
 func (m *{{.Name}}) Save(validate ...bool) (bool, *ar.Errors) {
+	return m.save(context.Background(), nil, validate...)
+}
+
+func (m *{{.Name}}) SaveTx(tx *ar.Tx, validate ...bool) (bool, *ar.Errors) {
+	return m.save(tx.Context(), tx, validate...)
+}
+
+func (m *{{.Name}}) SaveContext(ctx context.Context, validate ...bool) (bool, *ar.Errors) {
+	return m.save(ctx, nil, validate...)
+}
+
+func (m *{{.Name}}) save(ctx context.Context, tx *ar.Tx, validate ...bool) (bool, *ar.Errors) {
 	if len(validate) == 0 || len(validate) > 0 && validate[0] {
 		if ok, errs := m.IsValid(); !ok {
 			return false, errs
 		}
 	}
 	errs := &ar.Errors{}
+	{{if .HasCallback "beforeSave"}}
+	if err := m.beforeSave(); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}{{end}}
         if m.IsNewRecord() {
-                ins := ar.NewInsert(db, logger).Table("{{.TableName}}").Params(map[string]interface{}{ {{range .FieldsWithoutPrimaryKey}}
+		{{if .HasCallback "beforeCreate"}}
+		if err := m.beforeCreate(); err != nil {
+			errs.AddError("base", err)
+			return false, errs
+		}{{end}}
+		m.setCreateTimestamps()
+                ins := newInsert(tx).Table("{{.TableName}}").Params(map[string]interface{}{ {{range .FieldsWithoutPrimaryKey}}
 			"{{.ColumnName}}": m.{{.Name}},{{end}}
                 })
 
-                if result, err := ins.Exec(); err != nil {
+                if err := ins.ExecReturningContext(ctx, "{{.PrimaryKeyColumn}}", &m.{{.PrimaryKeyField}}); err != nil {
 			errs.AddError("base", err)
                         return false, errs
-                } else {
-			if lastId, err := result.LastInsertId(); err == nil {
-				m.{{.PrimaryKeyField}} = {{.PrimaryKeyType}}(lastId)
-			}
-		}
-                return true, nil
+                }
+		m.takeSnapshot()
+		{{if .HasCallback "afterCreate"}}
+		if err := m.afterCreate(); err != nil {
+			errs.AddError("base", err)
+			return false, errs
+		}{{end}}
         }else{
-		upd := ar.NewUpdate(db, logger).Table("{{.TableName}}").Params(map[string]interface{}{ {{range .Fields}}
-		"{{.ColumnName}}": m.{{.Name}},{{end}}
-		}).Where("{{.PrimaryKeyColumn}}", m.{{.PrimaryKeyField}})
-
-		if _, err := upd.Exec(); err != nil {
+		{{if .HasCallback "beforeUpdate"}}
+		if err := m.beforeUpdate(); err != nil {
 			errs.AddError("base", err)
 			return false, errs
+		}{{end}}
+		if columns := m.changedColumns(); len(columns) > 0 {
+			params := map[string]interface{}{}
+			for _, c := range columns {
+				params[c] = m.fieldValueByName(c)
+			}
+			{{if .HasUpdatedAt}}m.setUpdateTimestamps()
+			params["{{.UpdatedAtColumn}}"] = m.{{.UpdatedAtField}}{{end}}
+			{{if .HasLockVersion}}params["{{.LockVersionColumn}}"] = m.{{.LockVersionField}} + 1{{end}}
+			upd := newUpdate(tx).Table("{{.TableName}}").Params(params).Where("{{.PrimaryKeyColumn}}", m.{{.PrimaryKeyField}}){{if .HasLockVersion}}.Where("{{.LockVersionColumn}}", m.{{.LockVersionField}}){{end}}
+
+			{{if .HasLockVersion}}result, err := upd.ExecContext(ctx)
+			if err != nil {
+				errs.AddError("base", err)
+				return false, errs
+			}
+			if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+				errs.AddError("base", ar.ErrStaleObject)
+				return false, errs
+			}
+			m.{{.LockVersionField}}++{{else}}if _, err := upd.ExecContext(ctx); err != nil {
+				errs.AddError("base", err)
+				return false, errs
+			}{{end}}
+			m.takeSnapshot()
 		}
-                return true, nil
+		{{if .HasCallback "afterUpdate"}}
+		if err := m.afterUpdate(); err != nil {
+			errs.AddError("base", err)
+			return false, errs
+		}{{end}}
         }
+	{{if .HasCallback "afterSave"}}
+	if err := m.afterSave(); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}{{end}}
+	{{if .HasTransactionCallback}}m.afterTransaction(tx){{end}}
+        return true, nil
 }
 `}
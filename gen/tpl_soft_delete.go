@@ -0,0 +1,63 @@
+package gen
+
+var softDelete = &Template{
+	Name: "SoftDelete",
+	Text: `{{if .HasDeletedAt}}
+func (m {{.Name}}) WithDeleted() *{{.Name}}Relation {
+	return m.newRelation().WithDeleted()
+}
+
+func (r *{{.Name}}Relation) WithDeleted() *{{.Name}}Relation {
+	r.Relation.UnscopeWhere("{{.TableName}}.{{.DeletedAtColumn}}")
+	return r
+}
+
+func (m {{.Name}}) OnlyDeleted() *{{.Name}}Relation {
+	return m.newRelation().OnlyDeleted()
+}
+
+func (r *{{.Name}}Relation) OnlyDeleted() *{{.Name}}Relation {
+	r.WithDeleted().Relation.Where("{{.TableName}}.{{.DeletedAtColumn}}", "IS NOT", nil)
+	return r
+}
+
+func (m *{{.Name}}) IsDeleted() bool {
+	return m.{{.DeletedAtField}} != nil
+}
+
+func (m *{{.Name}}) Restore() (bool, *ar.Errors) {
+	return m.restore(context.Background(), nil)
+}
+
+func (m *{{.Name}}) RestoreTx(tx *ar.Tx) (bool, *ar.Errors) {
+	return m.restore(tx.Context(), tx)
+}
+
+func (m *{{.Name}}) RestoreContext(ctx context.Context) (bool, *ar.Errors) {
+	return m.restore(ctx, nil)
+}
+
+func (m *{{.Name}}) restore(ctx context.Context, tx *ar.Tx) (bool, *ar.Errors) {
+	errs := &ar.Errors{}
+	upd := newUpdate(tx).Table("{{.TableName}}").Params(map[string]interface{}{"{{.DeletedAtColumn}}": nil}).Where("{{.PrimaryKeyColumn}}", m.{{.PrimaryKeyField}})
+	if _, err := upd.ExecContext(ctx); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}
+	m.{{.DeletedAtField}} = nil
+	m.takeSnapshot("{{.DeletedAtColumn}}")
+	return true, nil
+}
+
+func (m *{{.Name}}) ReallyDestroy() (bool, *ar.Errors) {
+	return m.delete(context.Background(), nil, true)
+}
+
+func (m *{{.Name}}) ReallyDestroyTx(tx *ar.Tx) (bool, *ar.Errors) {
+	return m.delete(tx.Context(), tx, true)
+}
+
+func (m *{{.Name}}) ReallyDestroyContext(ctx context.Context) (bool, *ar.Errors) {
+	return m.delete(ctx, nil, true)
+}
+{{end}}`}
@@ -0,0 +1,22 @@
+package gen
+
+var timestamps = &Template{
+	Name: "Timestamps",
+	Text: `
+func (m *{{.Name}}) setCreateTimestamps() {
+{{if or .HasCreatedAt .HasUpdatedAt}}	now := ar.Now()
+{{if .HasCreatedAt}}	if m.{{.CreatedAtField}}.IsZero() {
+		m.{{.CreatedAtField}} = now
+	}
+{{end}}{{if .HasUpdatedAt}}	if m.{{.UpdatedAtField}}.IsZero() {
+		m.{{.UpdatedAtField}} = now
+	}
+{{end}}{{end}}}
+
+func (m *{{.Name}}) setUpdateTimestamps() {
+{{if .HasUpdatedAt}}{{if .HasSnapshot}}	if m.WasChanged("{{.UpdatedAtColumn}}") {
+		return
+	}
+{{end}}	m.{{.UpdatedAtField}} = ar.Now()
+{{end}}}
+`}
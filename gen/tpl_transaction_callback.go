@@ -0,0 +1,14 @@
+package gen
+
+var transactionCallback = &Template{
+	Name: "TransactionCallback",
+	Text: `
+func (m *{{.Name}}) afterTransaction(tx *ar.Tx) {
+	if tx == nil {
+		{{if .HasCallback "afterCommit"}}m.afterCommit(){{end}}
+		return
+	}
+	{{if .HasCallback "afterCommit"}}tx.AfterCommit(m.afterCommit){{end}}
+	{{if .HasCallback "afterRollback"}}tx.AfterRollback(m.afterRollback){{end}}
+}
+`}
@@ -0,0 +1,50 @@
+package gen
+
+var unscope = &Template{
+	Name: "Unscope",
+	Text: `
+func (m {{.Name}}) Unscoped() *{{.Name}}Relation {
+	return m.unscopedRelationTx(nil)
+}
+
+func (r *{{.Name}}Relation) Unscoped() *{{.Name}}Relation {
+	r.Relation.Unscoped()
+	return r
+}
+
+func (m {{.Name}}) Unscope(clauses ...query.Clause) *{{.Name}}Relation {
+	return m.newRelation().Unscope(clauses...)
+}
+
+func (r *{{.Name}}Relation) Unscope(clauses ...query.Clause) *{{.Name}}Relation {
+	r.Relation.Unscope(clauses...)
+	return r
+}
+
+func (m {{.Name}}) Rewhere(cond string, args ...interface{}) *{{.Name}}Relation {
+	return m.newRelation().Rewhere(cond, args...)
+}
+
+func (r *{{.Name}}Relation) Rewhere(cond string, args ...interface{}) *{{.Name}}Relation {
+	r.Relation.Rewhere(cond, args...)
+	return r
+}
+
+func (m {{.Name}}) Reorder(column, order string) *{{.Name}}Relation {
+	return m.newRelation().Reorder(column, order)
+}
+
+func (r *{{.Name}}Relation) Reorder(column, order string) *{{.Name}}Relation {
+	r.Relation.Reorder(column, order)
+	return r
+}
+
+func (m {{.Name}}) ReverseOrder() *{{.Name}}Relation {
+	return m.newRelation().ReverseOrder()
+}
+
+func (r *{{.Name}}Relation) ReverseOrder() *{{.Name}}Relation {
+	r.Relation.ReverseOrder()
+	return r
+}
+`}
@@ -4,18 +4,34 @@ var update = &Template{
 	Name: "Update",
 	Text: `
 func (m *{{.Name}}) Update(p {{.Name}}Params) (bool, *ar.Errors) {
+	return m.update(context.Background(), nil, p)
+}
+
+func (m *{{.Name}}) UpdateTx(tx *ar.Tx, p {{.Name}}Params) (bool, *ar.Errors) {
+	return m.update(tx.Context(), tx, p)
+}
+
+func (m *{{.Name}}) UpdateContext(ctx context.Context, p {{.Name}}Params) (bool, *ar.Errors) {
+	return m.update(ctx, nil, p)
+}
+
+func (m *{{.Name}}) update(ctx context.Context, tx *ar.Tx, p {{.Name}}Params, validate ...bool) (bool, *ar.Errors) {
 {{range .Fields}}
 	if !ar.IsZero(p.{{.Name}}) {
                 m.{{.Name}} = p.{{.Name}}
         }{{end}}
-	return m.Save()
+	return m.save(ctx, tx, validate...)
 }
 
 func (m *{{.Name}}) UpdateColumns(p {{.Name}}Params) (bool, *ar.Errors) {
-{{range .Fields}}
-	if !ar.IsZero(p.{{.Name}}) {
-                m.{{.Name}} = p.{{.Name}}
-        }{{end}}
-	return m.Save(false)
+	return m.update(context.Background(), nil, p, false)
+}
+
+func (m *{{.Name}}) UpdateColumnsTx(tx *ar.Tx, p {{.Name}}Params) (bool, *ar.Errors) {
+	return m.update(tx.Context(), tx, p, false)
+}
+
+func (m *{{.Name}}) UpdateColumnsContext(ctx context.Context, p {{.Name}}Params) (bool, *ar.Errors) {
+	return m.update(ctx, nil, p, false)
 }
 `}
@@ -0,0 +1,43 @@
+package gen
+
+var updateAll = &Template{
+	Name: "UpdateAll",
+	Text: `
+func (m {{.Name}}) UpdateAll(params interface{}) (int64, error) {
+	return m.newRelation().UpdateAll(params)
+}
+
+func (m {{.Name}}) UpdateAllContext(ctx context.Context, params interface{}) (int64, error) {
+	return m.newRelation().UpdateAllContext(ctx, params)
+}
+
+func (r *{{.Name}}Relation) UpdateAll(params interface{}) (int64, error) {
+	return r.UpdateAllContext(context.Background(), params)
+}
+
+func (r *{{.Name}}Relation) UpdateAllContext(ctx context.Context, params interface{}) (int64, error) {
+	var p map[string]interface{}
+	switch params := params.(type) {
+	case map[string]interface{}:
+		p = params
+	case {{.Name}}Params:
+		p = map[string]interface{}{}{{range .Fields}}
+		if !ar.IsZero(params.{{.Name}}) {
+			p["{{.ColumnName}}"] = params.{{.Name}}
+		}{{end}}
+	default:
+		return 0, fmt.Errorf("unsupported params type %T", params)
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	{{if .HasUpdatedAt}}if _, ok := p["{{.UpdatedAtColumn}}"]; !ok {
+		q := map[string]interface{}{"{{.UpdatedAtColumn}}": ar.Now()}
+		for k, v := range p {
+			q[k] = v
+		}
+		p = q
+	}{{end}}
+	return r.Relation.UpdateAllContext(ctx, p)
+}
+`}
@@ -0,0 +1,125 @@
+package gen
+
+var upsert = &Template{
+	Name: "Upsert",
+	Text: `
+func (m {{.Name}}) Upsert(p {{.Name}}Params, conflictColumns ...string) (*{{.Name}}, *ar.Errors) {
+	return m.upsert(context.Background(), nil, p, conflictColumns...)
+}
+
+func (m {{.Name}}) UpsertTx(tx *ar.Tx, p {{.Name}}Params, conflictColumns ...string) (*{{.Name}}, *ar.Errors) {
+	return m.upsert(tx.Context(), tx, p, conflictColumns...)
+}
+
+func (m {{.Name}}) UpsertContext(ctx context.Context, p {{.Name}}Params, conflictColumns ...string) (*{{.Name}}, *ar.Errors) {
+	return m.upsert(ctx, nil, p, conflictColumns...)
+}
+
+func (m {{.Name}}) upsert(ctx context.Context, tx *ar.Tx, p {{.Name}}Params, conflictColumns ...string) (*{{.Name}}, *ar.Errors) {
+	n := m.Build(p)
+	if ok, errs := n.IsValid(); !ok {
+		return nil, errs
+	}
+	errs := &ar.Errors{}
+
+	n.setCreateTimestamps()
+	params := map[string]interface{}{ {{range .FieldsWithoutPrimaryKey}}
+		"{{.ColumnName}}": n.{{.Name}},{{end}}
+	}
+	rel := newRelation(tx).Table("{{.TableName}}").Columns(n.columnNames()...)
+	for _, c := range conflictColumns {
+		if !n.isColumnName(c) {
+			errs.Add(c, "is not a column")
+			return nil, errs
+		}
+		v := n.fieldValueByName(c)
+		if _, ok := params[c]; !ok {
+			if ar.IsZero(v) {
+				errs.Add(c, "can't be blank")
+				return nil, errs
+			}
+			params[c] = v
+		}
+		rel.Where(c, v)
+	}
+	ins := newInsert(tx).Table("{{.TableName}}").Params(params).OnConflict(conflictColumns...)
+	{{if or .HasCreatedAt .HasLockVersion}}if columns := m.upsertColumns(conflictColumns); len(columns) > 0 {
+		ins.DoUpdate(columns...)
+	} else if len(conflictColumns) > 0 {
+		ins.DoUpdate(conflictColumns...)
+	} else {
+		ins.DoNothing()
+	}{{else}}ins.DoUpdate(){{end}}
+	if err := ins.ExecReturningContext(ctx, "{{.PrimaryKeyColumn}}", &n.{{.PrimaryKeyField}}); err != nil {
+		errs.AddError("base", err)
+		return nil, errs
+	}
+	if len(conflictColumns) == 0 {
+		rel.Where("{{.PrimaryKeyColumn}}", n.{{.PrimaryKeyField}})
+	}
+	if err := rel.QueryRowContext(ctx, n.fieldPtrsByName(n.columnNames())...); err != nil {
+		errs.AddError("base", err)
+		return nil, errs
+	}
+	n.takeSnapshot()
+	return n, nil
+}
+{{if or .HasCreatedAt .HasLockVersion}}
+func (m {{.Name}}) upsertColumns(conflictColumns []string) []string {
+	target := map[string]bool{}
+	for _, c := range conflictColumns {
+		target[c] = true
+	}
+	columns := []string{}
+	for _, c := range m.columnNames() {
+		if c == "{{.PrimaryKeyColumn}}" ||{{if .HasCreatedAt}} c == "{{.CreatedAtColumn}}" ||{{end}}{{if .HasLockVersion}} c == "{{.LockVersionColumn}}" ||{{end}} target[c] {
+			continue
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
+{{end}}
+func (m {{.Name}}) UpsertAll(ps []{{.Name}}Params, conflictColumns ...string) ([]*{{.Name}}, *ar.Errors) {
+	return m.upsertAll(context.Background(), nil, ps, conflictColumns...)
+}
+
+func (m {{.Name}}) UpsertAllTx(tx *ar.Tx, ps []{{.Name}}Params, conflictColumns ...string) ([]*{{.Name}}, *ar.Errors) {
+	return m.upsertAll(tx.Context(), tx, ps, conflictColumns...)
+}
+
+func (m {{.Name}}) UpsertAllContext(ctx context.Context, ps []{{.Name}}Params, conflictColumns ...string) ([]*{{.Name}}, *ar.Errors) {
+	return m.upsertAll(ctx, nil, ps, conflictColumns...)
+}
+
+func (m {{.Name}}) upsertAll(ctx context.Context, tx *ar.Tx, ps []{{.Name}}Params, conflictColumns ...string) ([]*{{.Name}}, *ar.Errors) {
+	var ns []*{{.Name}}
+	var errs *ar.Errors
+	fn := func(tx *ar.Tx) error {
+		for _, p := range ps {
+			n, e := m.upsert(ctx, tx, p, conflictColumns...)
+			if e != nil {
+				errs = e
+				return e
+			}
+			ns = append(ns, n)
+		}
+		return nil
+	}
+
+	var err error
+	if tx != nil {
+		err = tx.Transaction(fn)
+	} else {
+		err = TransactionContext(ctx, fn)
+	}
+	if err != nil {
+		if errs == nil {
+			errs = &ar.Errors{}
+			errs.AddError("base", err)
+		}
+		return nil, errs
+	}
+	return ns, nil
+}
+`}
@@ -3,9 +3,14 @@ package gen
 var validation = &Template{
 	Name: "Validation",
 	Text: `
-func (m {{.Name}}) IsValid() (bool, *ar.Errors) {
+func (m *{{.Name}}) IsValid() (bool, *ar.Errors) {
         result := true
 	errors := &ar.Errors{}
+	{{if .HasCallback "beforeValidation"}}
+	if err := m.beforeValidation(); err != nil {
+		errors.AddError("base", err)
+		return false, errors
+	}{{end}}
 	var on ar.On
 	if m.IsNewRecord() {
 		on = ar.OnCreate()
@@ -31,6 +36,11 @@ func (m {{.Name}}) IsValid() (bool, *ar.Errors) {
 	if len(errors.Messages) > 0 {
 		result = false
 	}
+	{{if .HasCallback "afterValidation"}}
+	if err := m.afterValidation(); err != nil {
+		errors.AddError("base", err)
+		result = false
+	}{{end}}
         return result, errors
 }
 `}
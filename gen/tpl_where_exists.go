@@ -0,0 +1,23 @@
+package gen
+
+var whereExists = &Template{
+	Name: "WhereExists",
+	Text: `
+func (m {{.Name}}) WhereExists(sub interface{}) *{{.Name}}Relation {
+        return m.newRelation().WhereExists(sub)
+}
+
+func (r *{{.Name}}Relation) WhereExists(sub interface{}) *{{.Name}}Relation {
+        r.Relation.WhereExists(sub)
+        return r
+}
+
+func (m {{.Name}}) WhereNotExists(sub interface{}) *{{.Name}}Relation {
+        return m.newRelation().WhereNotExists(sub)
+}
+
+func (r *{{.Name}}Relation) WhereNotExists(sub interface{}) *{{.Name}}Relation {
+        r.Relation.WhereNotExists(sub)
+        return r
+}
+`}
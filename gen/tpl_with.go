@@ -0,0 +1,23 @@
+package gen
+
+var with = &Template{
+	Name: "With",
+	Text: `
+func (m {{.Name}}) With(name string, query interface{}) *{{.Name}}Relation {
+        return m.newRelation().With(name, query)
+}
+
+func (r *{{.Name}}Relation) With(name string, query interface{}) *{{.Name}}Relation {
+        r.Relation.With(name, query)
+        return r
+}
+
+func (m {{.Name}}) WithRecursive(name string, query interface{}) *{{.Name}}Relation {
+        return m.newRelation().WithRecursive(name, query)
+}
+
+func (r *{{.Name}}Relation) WithRecursive(name string, query interface{}) *{{.Name}}Relation {
+        r.Relation.WithRecursive(name, query)
+        return r
+}
+`}
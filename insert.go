@@ -1,7 +1,9 @@
 package ar
 
 import (
+	"context"
 	"database/sql"
+	"reflect"
 
 	"github.com/monochromegane/argen/query"
 )
@@ -12,12 +14,30 @@ type Insert struct {
 }
 
 func NewInsert(db *sql.DB, logger *Logger) *Insert {
+	return newInsert(db, logger)
+}
+
+func NewInsertTx(tx *Tx, logger *Logger) *Insert {
+	return newInsert(tx, logger)
+}
+
+func newInsert(conn Conn, logger *Logger) *Insert {
 	return &Insert{
 		Insert: &query.Insert{},
-		exec:   &Executer{db, logger},
+		exec:   &Executer{conn: conn, logger: logger},
 	}
 }
 
+func (i *Insert) Dialect(d query.Dialect) *Insert {
+	i.Insert.Dialect(d)
+	return i
+}
+
+func (i *Insert) Cache(c *StmtCache) *Insert {
+	i.exec.cache = c
+	return i
+}
+
 func (i *Insert) Table(table string) *Insert {
 	i.Insert.Table(table)
 	return i
@@ -28,7 +48,97 @@ func (i *Insert) Params(params map[string]interface{}) *Insert {
 	return i
 }
 
+func (i *Insert) Rows(rows ...map[string]interface{}) *Insert {
+	i.Insert.Rows(rows...)
+	return i
+}
+
+func (i *Insert) OnConflict(columns ...string) *Insert {
+	i.Insert.OnConflict(columns...)
+	return i
+}
+
+func (i *Insert) DoNothing() *Insert {
+	i.Insert.DoNothing()
+	return i
+}
+
+func (i *Insert) DoUpdate(columns ...string) *Insert {
+	i.Insert.DoUpdate(columns...)
+	return i
+}
+
+func (i *Insert) Returning(columns ...string) *Insert {
+	i.Insert.Returning(columns...)
+	return i
+}
+
 func (i *Insert) Exec() (sql.Result, error) {
+	return i.ExecContext(context.Background())
+}
+
+func (i *Insert) ExecContext(ctx context.Context) (sql.Result, error) {
+	if err := i.ConflictError(); err != nil {
+		return nil, err
+	}
 	q, b := i.Insert.Build()
-	return i.exec.Exec(q, b...)
+	return i.exec.ExecContext(ctx, q, b...)
+}
+
+func (i *Insert) ExecAll() (int64, error) {
+	return i.ExecAllContext(context.Background())
+}
+
+func (i *Insert) ExecAllContext(ctx context.Context) (int64, error) {
+	if err := i.ConflictError(); err != nil {
+		return 0, err
+	}
+	var affected int64
+	for _, chunk := range i.Insert.Chunks() {
+		q, b := chunk.Build()
+		result, err := i.exec.ExecContext(ctx, q, b...)
+		if err != nil {
+			return affected, err
+		}
+		if n, err := result.RowsAffected(); err == nil {
+			affected += n
+		}
+	}
+	return affected, nil
+}
+
+func (i *Insert) QueryRow(dest ...interface{}) error {
+	return i.QueryRowContext(context.Background(), dest...)
+}
+
+func (i *Insert) QueryRowContext(ctx context.Context, dest ...interface{}) error {
+	if err := i.ConflictError(); err != nil {
+		return err
+	}
+	q, b := i.Insert.Build()
+	return i.exec.QueryRowContext(ctx, q, b...).Scan(dest...)
+}
+
+func (i *Insert) ExecReturning(pk string, dest interface{}) error {
+	return i.ExecReturningContext(context.Background(), pk, dest)
+}
+
+func (i *Insert) ExecReturningContext(ctx context.Context, pk string, dest interface{}) error {
+	if d := i.GetDialect(); d != nil && d.SupportsReturning() {
+		return i.Returning(pk).QueryRowContext(ctx, dest)
+	}
+	result, err := i.ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+	if lastId, err := result.LastInsertId(); err == nil {
+		v := reflect.ValueOf(dest).Elem()
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v.SetInt(lastId)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v.SetUint(uint64(lastId))
+		}
+	}
+	return nil
 }
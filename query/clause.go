@@ -0,0 +1,16 @@
+package query
+
+type Clause string
+
+const (
+	WhereClause  Clause = "where"
+	OrderClause  Clause = "order"
+	LimitClause  Clause = "limit"
+	OffsetClause Clause = "offset"
+	GroupClause  Clause = "group"
+	HavingClause Clause = "having"
+	JoinsClause  Clause = "joins"
+	LockClause   Clause = "lock"
+)
+
+var Clauses = []Clause{WhereClause, OrderClause, LimitClause, OffsetClause, GroupClause, HavingClause, JoinsClause, LockClause}
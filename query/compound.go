@@ -0,0 +1,28 @@
+package query
+
+import "fmt"
+
+type sortable interface {
+	isSorted() bool
+}
+
+type compound struct {
+	operator string
+	query    subquery
+}
+
+func newCompound(operator string, query interface{}) *compound {
+	sub, ok := query.(subquery)
+	if !ok {
+		return nil
+	}
+	return &compound{operator: operator, query: sub}
+}
+
+func (c *compound) build(d Dialect) (string, []interface{}) {
+	q, b := c.query.buildSubquery(d)
+	if s, ok := c.query.(sortable); ok && s.isSorted() {
+		return fmt.Sprintf(" %s SELECT * FROM (%s) AS t", c.operator, q), b
+	}
+	return fmt.Sprintf(" %s %s", c.operator, q), b
+}
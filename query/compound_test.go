@@ -0,0 +1,33 @@
+package query
+
+import "testing"
+
+func TestCompound(t *testing.T) {
+	s := &Select{}
+	s.Table("table").Columns("columnA").Where("columnB", "value")
+
+	c := newCompound("UNION ALL", s)
+
+	q, b := c.build(defaultDialect{})
+
+	assertQuery(t, " UNION ALL SELECT columnA FROM table WHERE columnB = ?", q)
+	assertBinds(t, []interface{}{"value"}, b)
+}
+
+func TestCompoundWithoutSubquery(t *testing.T) {
+	if c := newCompound("UNION", "SELECT 1"); c != nil {
+		t.Errorf("compound should be nil, but %v", c)
+	}
+}
+
+func TestCompoundWithOrderAndLimit(t *testing.T) {
+	s := &Select{}
+	s.Table("table").Columns("columnA").OrderBy("columnA", "DESC").Limit(1)
+
+	c := newCompound("UNION", s)
+
+	q, b := c.build(defaultDialect{})
+
+	assertQuery(t, " UNION SELECT * FROM (SELECT columnA FROM table ORDER BY columnA DESC LIMIT ?) AS t", q)
+	assertBinds(t, []interface{}{1}, b)
+}
@@ -1,49 +1,342 @@
 package query
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 )
 
+const (
+	AND = "AND"
+	OR  = "OR"
+)
+
+var ErrInvalidCondition = errors.New("invalid condition")
+
 type condition struct {
 	phrase      string
 	expressions []expression
+	err         error
 }
 
 type expression struct {
-	cond string
-	args []interface{}
+	cond   string
+	args   []interface{}
+	conj   string
+	not    bool
+	group  *condition
+	exists subquery
+}
+
+type conditional interface {
+	whereCondition() *condition
+}
+
+type subquery interface {
+	buildSubquery(d Dialect) (string, []interface{})
 }
 
-func (e expression) build() (string, []interface{}) {
+func (e expression) build(d Dialect) (string, []interface{}) {
+	if e.group != nil {
+		return e.buildGroup(d)
+	}
+	if e.exists != nil {
+		return e.buildExists(d)
+	}
 	var query string
 	var binds []interface{}
 	switch len(e.args) {
 	case 0:
 		query = e.cond
 	case 1:
-		query = fmt.Sprintf("%s = ?", e.cond)
-		binds = append(binds, e.args[0])
+		query, binds = e.buildOperator(d, "=", e.args[0])
 	case 2:
-		query = fmt.Sprintf("%s %s ?", e.cond, e.args[0])
-		binds = append(binds, e.args[1])
+		query, binds = e.buildOperator(d, fmt.Sprintf("%v", e.args[0]), e.args[1])
+	case 3:
+		query, binds = e.buildBetween(d, fmt.Sprintf("%v", e.args[0]), e.args[1], e.args[2])
 	default:
 		query = ""
 	}
+	if e.not {
+		query = fmt.Sprintf("NOT (%s)", query)
+	}
+	return query, binds
+}
+
+func (e expression) buildOperator(d Dialect, op string, value interface{}) (string, []interface{}) {
+	op = strings.ToUpper(op)
+	column := quoteIdentifier(d, e.cond)
+	if value == nil {
+		switch op {
+		case "=", "IS":
+			return fmt.Sprintf("%s IS NULL", column), nil
+		case "<>", "!=", "IS NOT":
+			return fmt.Sprintf("%s IS NOT NULL", column), nil
+		}
+	}
+	if sub, ok := value.(subquery); ok {
+		switch op {
+		case "=":
+			op = "IN"
+		case "<>", "!=":
+			op = "NOT IN"
+		}
+		q, b := sub.buildSubquery(d)
+		return fmt.Sprintf("%s %s (%s)", column, op, q), b
+	}
+	if values, ok := toSlice(value); ok {
+		switch op {
+		case "=":
+			op = "IN"
+		case "<>", "!=":
+			op = "NOT IN"
+		}
+		if len(values) == 0 {
+			return d.Bool(op == "NOT IN"), nil
+		}
+		return fmt.Sprintf("%s %s (%s)", column, op, placeholders(len(values))), values
+	}
+	if expr, ok := value.(Expression); ok {
+		q, b := expr.build(d)
+		return fmt.Sprintf("%s %s %s", column, op, q), b
+	}
+	if like, ok := value.(Like); ok {
+		if op == "=" {
+			op = "LIKE"
+		}
+		return fmt.Sprintf("%s %s ? ESCAPE '%s'", column, op, likeEscape), []interface{}{string(like)}
+	}
+	return fmt.Sprintf("%s %s ?", column, op), []interface{}{value}
+}
+
+func (e expression) buildBetween(d Dialect, op string, from, to interface{}) (string, []interface{}) {
+	return fmt.Sprintf("%s %s ? AND ?", quoteIdentifier(d, e.cond), strings.ToUpper(op)), []interface{}{from, to}
+}
+
+func (e expression) buildExists(d Dialect) (string, []interface{}) {
+	q, b := e.exists.buildSubquery(d)
+	if e.not {
+		return fmt.Sprintf("NOT EXISTS (%s)", q), b
+	}
+	return fmt.Sprintf("EXISTS (%s)", q), b
+}
+
+func (e expression) buildGroup(d Dialect) (string, []interface{}) {
+	query, binds := e.group.buildExpressions(d)
+	if e.not {
+		return fmt.Sprintf("NOT (%s)", query), binds
+	}
+	if len(e.group.expressions) > 1 {
+		query = fmt.Sprintf("(%s)", query)
+	}
 	return query, binds
 }
 
 func (c *condition) addExpression(cond string, args ...interface{}) {
-	c.expressions = append(c.expressions, expression{cond, args})
+	c.add(AND, false, cond, args...)
 }
 
-func (c *condition) build() (string, []interface{}) {
-	var queries []string
-	var binds []interface{}
+func (c *condition) add(conj string, not bool, cond interface{}, args ...interface{}) {
+	e := expression{conj: conj, not: not}
+	switch cond := cond.(type) {
+	case string:
+		if !validArgs(args) {
+			c.setError(fmt.Errorf("%w: %s with %d args", ErrInvalidCondition, cond, len(args)))
+			return
+		}
+		e.cond = cond
+		e.args = args
+	case conditional:
+		group := cond.whereCondition()
+		if group == nil {
+			return
+		}
+		c.setError(group.err)
+		if len(group.expressions) == 0 {
+			return
+		}
+		e.group = group.clone()
+	default:
+		c.setError(fmt.Errorf("%w: unsupported type %T", ErrInvalidCondition, cond))
+		return
+	}
+	c.expressions = append(c.expressions, e)
+}
+
+func (c *condition) addExists(conj string, not bool, sub interface{}) {
+	if sub, ok := sub.(subquery); ok {
+		c.expressions = append(c.expressions, expression{conj: conj, not: not, exists: sub})
+	}
+}
+
+func (c *condition) remove(columns ...string) {
+	if c == nil {
+		return
+	}
+	remove := map[string]bool{}
+	for _, column := range columns {
+		remove[column] = true
+	}
+	expressions := []expression{}
+	for _, e := range c.expressions {
+		if e.group == nil && e.exists == nil && remove[e.cond] {
+			continue
+		}
+		expressions = append(expressions, e)
+	}
+	c.expressions = expressions
+}
+
+func (c *condition) removeAll(columns ...string) {
+	if c == nil {
+		return
+	}
+	c.remove(columns...)
+	expressions := []expression{}
 	for _, e := range c.expressions {
-		q, b := e.build()
-		queries = append(queries, q)
+		if e.group != nil {
+			e.group = e.group.clone()
+			e.group.removeAll(columns...)
+			if len(e.group.expressions) == 0 {
+				continue
+			}
+		}
+		expressions = append(expressions, e)
+	}
+	c.expressions = expressions
+}
+
+func (c *condition) setError(err error) {
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+func (c *condition) error() error {
+	if c == nil {
+		return nil
+	}
+	return c.err
+}
+
+func (c *condition) isEmpty() bool {
+	return c == nil || len(c.expressions) == 0
+}
+
+func (c *condition) clone() *condition {
+	return &condition{
+		phrase:      c.phrase,
+		expressions: append([]expression{}, c.expressions...),
+		err:         c.err,
+	}
+}
+
+func (c *condition) buildExpressions(d Dialect) (string, []interface{}) {
+	var query string
+	var binds []interface{}
+	var conj string
+	for i, e := range c.expressions {
+		q, b := e.build(d)
 		binds = append(binds, b...)
+		if i == 0 {
+			query = q
+			continue
+		}
+		if conj != "" && conj != e.conj {
+			query = fmt.Sprintf("(%s)", query)
+		}
+		query = strings.Join([]string{query, e.conj, q}, " ")
+		conj = e.conj
+	}
+	return query, binds
+}
+
+func (c *condition) build(d Dialect) (string, []interface{}) {
+	query, binds := c.buildExpressions(d)
+	return fmt.Sprintf(" %s %s", c.phrase, query), binds
+}
+
+func validArgs(args []interface{}) bool {
+	switch len(args) {
+	case 0, 1, 2:
+		return true
+	case 3:
+		switch strings.ToUpper(fmt.Sprintf("%v", args[0])) {
+		case "BETWEEN", "NOT BETWEEN":
+			return true
+		}
+	}
+	return false
+}
+
+func toSlice(value interface{}) ([]interface{}, bool) {
+	if _, ok := value.([]byte); ok {
+		return nil, false
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
 	}
-	return fmt.Sprintf(" %s %s", c.phrase, strings.Join(queries, " AND ")), binds
+	values := make([]interface{}, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values, true
+}
+
+func placeholders(n int) string {
+	ph := make([]string, n)
+	for i := range ph {
+		ph[i] = "?"
+	}
+	return strings.Join(ph, ", ")
+}
+
+type Group struct {
+	where *condition
+}
+
+func (g *Group) Where(cond interface{}, args ...interface{}) *Group {
+	return g.add(AND, false, cond, args...)
+}
+
+func (g *Group) And(cond interface{}, args ...interface{}) *Group {
+	return g.Where(cond, args...)
+}
+
+func (g *Group) Or(cond interface{}, args ...interface{}) *Group {
+	return g.add(OR, false, cond, args...)
+}
+
+func (g *Group) Not(cond interface{}, args ...interface{}) *Group {
+	return g.add(AND, true, cond, args...)
+}
+
+func (g *Group) WhereExists(sub interface{}) *Group {
+	return g.addExists(false, sub)
+}
+
+func (g *Group) WhereNotExists(sub interface{}) *Group {
+	return g.addExists(true, sub)
+}
+
+func (g *Group) addExists(not bool, sub interface{}) *Group {
+	if g.where == nil {
+		g.where = &condition{}
+	}
+	g.where.addExists(AND, not, sub)
+	return g
+}
+
+func (g *Group) add(conj string, not bool, cond interface{}, args ...interface{}) *Group {
+	if g.where == nil {
+		g.where = &condition{}
+	}
+	g.where.add(conj, not, cond, args...)
+	return g
+}
+
+func (g *Group) whereCondition() *condition {
+	return g.where
 }
@@ -1,12 +1,15 @@
 package query
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestOneCondition(t *testing.T) {
 	c := condition{phrase: "WHERE"}
 	c.addExpression("columnA = 'const'")
 
-	q, b := c.build()
+	q, b := c.build(defaultDialect{})
 
 	assertQuery(t, " WHERE columnA = 'const'", q)
 	assertEmptyBinds(t, b)
@@ -16,7 +19,7 @@ func TestTwoCondition(t *testing.T) {
 	c := condition{phrase: "WHERE"}
 	c.addExpression("columnA", "value")
 
-	q, b := c.build()
+	q, b := c.build(defaultDialect{})
 
 	assertQuery(t, " WHERE columnA = ?", q)
 	assertBinds(t, []interface{}{"value"}, b)
@@ -26,7 +29,7 @@ func TestThreeCondition(t *testing.T) {
 	c := condition{phrase: "WHERE"}
 	c.addExpression("columnA", "<>", "value")
 
-	q, b := c.build()
+	q, b := c.build(defaultDialect{})
 
 	assertQuery(t, " WHERE columnA <> ?", q)
 	assertBinds(t, []interface{}{"value"}, b)
@@ -37,8 +40,179 @@ func TestConditionWithMultiExpression(t *testing.T) {
 	c.addExpression("columnA", "value1")
 	c.addExpression("columnB", "value2")
 
-	q, b := c.build()
+	q, b := c.build(defaultDialect{})
 
 	assertQuery(t, " WHERE columnA = ? AND columnB = ?", q)
 	assertBinds(t, []interface{}{"value1", "value2"}, b)
 }
+
+func TestOrCondition(t *testing.T) {
+	c := condition{phrase: "WHERE"}
+	c.add(AND, false, "columnA", "value1")
+	c.add(OR, false, "columnB", "value2")
+
+	q, b := c.build(defaultDialect{})
+
+	assertQuery(t, " WHERE columnA = ? OR columnB = ?", q)
+	assertBinds(t, []interface{}{"value1", "value2"}, b)
+}
+
+func TestMixedCondition(t *testing.T) {
+	c := condition{phrase: "WHERE"}
+	c.add(AND, false, "columnA", "value1")
+	c.add(AND, false, "columnB", "value2")
+	c.add(OR, false, "columnC", "value3")
+	c.add(AND, false, "columnD", "value4")
+
+	q, b := c.build(defaultDialect{})
+
+	assertQuery(t, " WHERE ((columnA = ? AND columnB = ?) OR columnC = ?) AND columnD = ?", q)
+	assertBinds(t, []interface{}{"value1", "value2", "value3", "value4"}, b)
+}
+
+func TestNotCondition(t *testing.T) {
+	c := condition{phrase: "WHERE"}
+	c.add(AND, false, "columnA", "value1")
+	c.add(AND, true, "columnB", "<>", "value2")
+
+	q, b := c.build(defaultDialect{})
+
+	assertQuery(t, " WHERE columnA = ? AND NOT (columnB <> ?)", q)
+	assertBinds(t, []interface{}{"value1", "value2"}, b)
+}
+
+func TestGroupCondition(t *testing.T) {
+	g := &Group{}
+	g.Where("columnB", "value2").Or("columnC", "value3")
+
+	c := condition{phrase: "WHERE"}
+	c.add(AND, false, "columnA", "value1")
+	c.add(AND, false, g)
+	c.add(AND, true, g)
+
+	q, b := c.build(defaultDialect{})
+
+	assertQuery(t, " WHERE columnA = ? AND (columnB = ? OR columnC = ?) AND NOT (columnB = ? OR columnC = ?)", q)
+	assertBinds(t, []interface{}{"value1", "value2", "value3", "value2", "value3"}, b)
+}
+
+func TestInCondition(t *testing.T) {
+	c := condition{phrase: "WHERE"}
+	c.addExpression("columnA", []int{1, 2, 3})
+	c.addExpression("columnB", "NOT IN", []string{"value1", "value2"})
+
+	q, b := c.build(defaultDialect{})
+
+	assertQuery(t, " WHERE columnA IN (?, ?, ?) AND columnB NOT IN (?, ?)", q)
+	assertBinds(t, []interface{}{1, 2, 3, "value1", "value2"}, b)
+}
+
+func TestEmptyInCondition(t *testing.T) {
+	c := condition{phrase: "WHERE"}
+	c.addExpression("columnA", "IN", []int{})
+	c.addExpression("columnB", "NOT IN", []int{})
+
+	q, b := c.build(defaultDialect{})
+
+	assertQuery(t, " WHERE 1 = 0 AND 1 = 1", q)
+	assertEmptyBinds(t, b)
+}
+
+func TestBetweenCondition(t *testing.T) {
+	c := condition{phrase: "WHERE"}
+	c.addExpression("columnA", "BETWEEN", 1, 10)
+
+	q, b := c.build(defaultDialect{})
+
+	assertQuery(t, " WHERE columnA BETWEEN ? AND ?", q)
+	assertBinds(t, []interface{}{1, 10}, b)
+}
+
+func TestInvalidArgsCondition(t *testing.T) {
+	for _, args := range [][]interface{}{
+		{">", 1, 10},
+		{"BETWEEN", 1, 10, 100},
+		{"IN", 1, 2},
+	} {
+		c := condition{phrase: "WHERE"}
+		c.addExpression("columnA", args...)
+
+		if !errors.Is(c.error(), ErrInvalidCondition) {
+			t.Errorf("condition with %v should fail with %v, but %v", args, ErrInvalidCondition, c.error())
+		}
+	}
+
+	c := condition{phrase: "WHERE"}
+	c.add(AND, false, 1)
+	if !errors.Is(c.error(), ErrInvalidCondition) {
+		t.Errorf("condition with unsupported type should fail with %v, but %v", ErrInvalidCondition, c.error())
+	}
+
+	c = condition{phrase: "WHERE"}
+	c.addExpression("columnA", "not between", 1, 10)
+	if err := c.error(); err != nil {
+		t.Errorf("condition should not fail, but %v", err)
+	}
+}
+
+func TestNullCondition(t *testing.T) {
+	c := condition{phrase: "WHERE"}
+	c.addExpression("columnA", nil)
+	c.addExpression("columnB", "<>", nil)
+
+	q, b := c.build(defaultDialect{})
+
+	assertQuery(t, " WHERE columnA IS NULL AND columnB IS NOT NULL", q)
+	assertEmptyBinds(t, b)
+}
+
+func TestLikeCondition(t *testing.T) {
+	c := condition{phrase: "WHERE"}
+	c.addExpression("columnA", "LIKE", Contains("50%"))
+	c.addExpression("columnB", "NOT LIKE", "a%")
+
+	q, b := c.build(defaultDialect{})
+
+	assertQuery(t, " WHERE columnA LIKE ? ESCAPE '!' AND columnB NOT LIKE ?", q)
+	assertBinds(t, []interface{}{"%50!%%", "a%"}, b)
+}
+
+func TestSubqueryCondition(t *testing.T) {
+	s := &Select{}
+	s.Table("tableB").Columns("table_id").Where("columnB", "value")
+
+	c := condition{phrase: "WHERE"}
+	c.addExpression("columnA", 1)
+	c.addExpression("id", "IN", s)
+	c.addExpression("id", "<>", s)
+
+	q, b := c.build(defaultDialect{})
+
+	assertQuery(t, " WHERE columnA = ? AND id IN (SELECT table_id FROM tableB WHERE columnB = ?) AND id NOT IN (SELECT table_id FROM tableB WHERE columnB = ?)", q)
+	assertBinds(t, []interface{}{1, "value", "value"}, b)
+}
+
+func TestExistsCondition(t *testing.T) {
+	s := &Select{}
+	s.Table("tableB").Columns("1").Where("tableB.table_id = table.id").And("columnB", "value")
+
+	c := condition{phrase: "WHERE"}
+	c.addExists(AND, false, s)
+	c.addExists(OR, true, s)
+
+	q, b := c.build(defaultDialect{})
+
+	assertQuery(t, " WHERE EXISTS (SELECT 1 FROM tableB WHERE tableB.table_id = table.id AND columnB = ?) OR NOT EXISTS (SELECT 1 FROM tableB WHERE tableB.table_id = table.id AND columnB = ?)", q)
+	assertBinds(t, []interface{}{"value", "value"}, b)
+}
+
+func TestExpressionCondition(t *testing.T) {
+	c := condition{phrase: "WHERE"}
+	c.addExpression("columnA", "<", Expr("columnB + ?", 1))
+	c.addExpression("columnC", Expr("CURRENT_TIMESTAMP"))
+
+	q, b := c.build(defaultDialect{})
+
+	assertQuery(t, " WHERE columnA < columnB + ? AND columnC = CURRENT_TIMESTAMP", q)
+	assertBinds(t, []interface{}{1}, b)
+}
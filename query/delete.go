@@ -3,8 +3,15 @@ package query
 import "fmt"
 
 type Delete struct {
-	table string
-	where *condition
+	dialect   Dialect
+	table     string
+	where     *condition
+	returning []string
+}
+
+func (d *Delete) Dialect(dialect Dialect) *Delete {
+	d.dialect = dialect
+	return d
 }
 
 func (d *Delete) Table(table string) *Delete {
@@ -12,25 +19,66 @@ func (d *Delete) Table(table string) *Delete {
 	return d
 }
 
-func (d *Delete) Where(cond string, args ...interface{}) *Delete {
+func (d *Delete) Where(cond interface{}, args ...interface{}) *Delete {
+	return d.addWhere(AND, false, cond, args...)
+}
+
+func (d *Delete) And(cond interface{}, args ...interface{}) *Delete {
+	return d.Where(cond, args...)
+}
+
+func (d *Delete) Or(cond interface{}, args ...interface{}) *Delete {
+	return d.addWhere(OR, false, cond, args...)
+}
+
+func (d *Delete) Not(cond interface{}, args ...interface{}) *Delete {
+	return d.addWhere(AND, true, cond, args...)
+}
+
+func (d *Delete) addWhere(conj string, not bool, cond interface{}, args ...interface{}) *Delete {
 	if d.where == nil {
 		d.where = &condition{phrase: "WHERE"}
 	}
-	d.where.addExpression(cond, args...)
+	d.where.add(conj, not, cond, args...)
 	return d
 }
 
-func (d *Delete) And(cond string, args ...interface{}) *Delete {
-	return d.Where(cond, args...)
+func (d *Delete) Merge(s *Select, pk string) *Delete {
+	d.table = s.table
+	if err := s.ConditionError(); err != nil {
+		if d.where == nil {
+			d.where = &condition{phrase: "WHERE"}
+		}
+		d.where.setError(err)
+	}
+	if s.isSimple() {
+		return d.Where(s)
+	}
+	return d.Where(pk, "IN", s.primaryKeys(pk))
+}
+
+func (d *Delete) Returning(columns ...string) *Delete {
+	d.returning = columns
+	return d
+}
+
+func (d *Delete) ConditionError() error {
+	return d.where.error()
+}
+
+func (d *Delete) whereCondition() *condition {
+	return d.where
 }
 
 func (d *Delete) Build() (string, []interface{}) {
+	dialect := dialectOf(d.dialect)
 	binds := []interface{}{}
-	query := fmt.Sprintf("DELETE FROM %s", d.table)
-	if d.where != nil {
-		q, b := d.where.build()
+	query := fmt.Sprintf("DELETE FROM %s", quoteIdentifier(dialect, d.table))
+	if !d.where.isEmpty() {
+		q, b := d.where.build(dialect)
 		query += q
 		binds = append(binds, b...)
 	}
-	return query + ";", binds
+	query += buildReturning(dialect, d.returning)
+	return rebind(dialect, query+";"), binds
 }
@@ -1,6 +1,9 @@
 package query
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestDelete(t *testing.T) {
 	del := Delete{}
@@ -12,3 +15,72 @@ func TestDelete(t *testing.T) {
 	assertQuery(t, "DELETE FROM table WHERE columnA = ?;", q)
 	assertBinds(t, []interface{}{"value1", "value2", "value1"}, b)
 }
+
+func TestDeleteReturning(t *testing.T) {
+	d := Delete{}
+	d.Table("table")
+	d.Where("columnA", "value")
+	d.Returning("id", "columnA")
+
+	q, b := d.Build()
+
+	assertQuery(t, "DELETE FROM table WHERE columnA = ? RETURNING id, columnA;", q)
+	assertBinds(t, []interface{}{"value"}, b)
+}
+
+func TestDeleteMerge(t *testing.T) {
+	s := &Select{}
+	s.Table("table").Columns("columnA")
+
+	d := Delete{}
+	d.Merge(s, "id")
+
+	q, b := d.Build()
+
+	assertQuery(t, "DELETE FROM table;", q)
+	assertEmptyBinds(t, b)
+
+	s.Where("columnA", "value").OrderBy("columnA", "ASC").Limit(10)
+
+	d = Delete{}
+	d.Merge(s, "id")
+
+	q, b = d.Build()
+
+	assertQuery(t, "DELETE FROM table WHERE id IN (SELECT id FROM (SELECT table.id FROM table WHERE columnA = ? ORDER BY columnA ASC LIMIT ?) AS t);", q)
+	assertBinds(t, []interface{}{"value", 10}, b)
+}
+
+func TestDeleteMergeScope(t *testing.T) {
+	s := &Select{}
+	s.Table("table").Columns("columnA")
+	s.Scope("table.deleted_at", nil)
+	s.Where("columnA", "value1").Or("columnA", "value2")
+
+	d := Delete{}
+	d.Merge(s, "id")
+
+	q, b := d.Build()
+
+	assertQuery(t, "DELETE FROM table WHERE (table.deleted_at IS NULL AND (columnA = ? OR columnA = ?));", q)
+	assertBinds(t, []interface{}{"value1", "value2"}, b)
+}
+
+func TestDeleteInvalidCondition(t *testing.T) {
+	d := Delete{}
+	d.Table("table").Where("id", "IN", 1, 2)
+
+	if !errors.Is(d.ConditionError(), ErrInvalidCondition) {
+		t.Errorf("delete should fail with %v, but %v", ErrInvalidCondition, d.ConditionError())
+	}
+
+	s := &Select{}
+	s.Table("table").Where("id", "IN", 1, 2)
+
+	d = Delete{}
+	d.Merge(s, "id")
+
+	if !errors.Is(d.ConditionError(), ErrInvalidCondition) {
+		t.Errorf("merged delete should fail with %v, but %v", ErrInvalidCondition, d.ConditionError())
+	}
+}
@@ -0,0 +1,340 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type Dialect interface {
+	Placeholder(n int) string
+	Quote(identifier string) string
+	LimitOffset(limit, offset *int) (string, []interface{})
+	Bool(b bool) string
+	SupportsReturning() bool
+	OnConflict(columns, target, updates []string) (string, error)
+	MaxPlaceholders() int
+	Lock(mode LockMode) (string, bool)
+}
+
+var (
+	SQLite     Dialect = sqlite{}
+	MySQL      Dialect = mysql{}
+	PostgreSQL Dialect = postgresql{}
+	Oracle     Dialect = oracle{}
+)
+
+type defaultDialect struct{}
+
+func (defaultDialect) Placeholder(n int) string {
+	return "?"
+}
+
+func (defaultDialect) Quote(identifier string) string {
+	return identifier
+}
+
+func (defaultDialect) LimitOffset(limit, offset *int) (string, []interface{}) {
+	if limit == nil && offset != nil {
+		l := -1
+		limit = &l
+	}
+	return limitOffset(limit, offset)
+}
+
+func (defaultDialect) Bool(b bool) string {
+	if b {
+		return "1 = 1"
+	}
+	return "1 = 0"
+}
+
+func (defaultDialect) SupportsReturning() bool {
+	return false
+}
+
+func (defaultDialect) MaxPlaceholders() int {
+	return 999
+}
+
+func (defaultDialect) OnConflict(columns, target, updates []string) (string, error) {
+	return onConflict(target, updates), nil
+}
+
+func (defaultDialect) Lock(mode LockMode) (string, bool) {
+	return "", false
+}
+
+type sqlite struct {
+	defaultDialect
+}
+
+func (sqlite) Quote(identifier string) string {
+	return fmt.Sprintf(`"%s"`, strings.Replace(identifier, `"`, `""`, -1))
+}
+
+func (sqlite) Bool(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func (sqlite) Lock(mode LockMode) (string, bool) {
+	return "", false
+}
+
+type mysql struct{}
+
+func (mysql) Placeholder(n int) string {
+	return "?"
+}
+
+func (mysql) Quote(identifier string) string {
+	return fmt.Sprintf("`%s`", strings.Replace(identifier, "`", "``", -1))
+}
+
+func (mysql) LimitOffset(limit, offset *int) (string, []interface{}) {
+	if limit == nil && offset != nil {
+		return " LIMIT 18446744073709551615 OFFSET ?", []interface{}{*offset}
+	}
+	return limitOffset(limit, offset)
+}
+
+func (mysql) Bool(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+func (mysql) SupportsReturning() bool {
+	return false
+}
+
+func (mysql) MaxPlaceholders() int {
+	return 65535
+}
+
+func (mysql) OnConflict(columns, target, updates []string) (string, error) {
+	if len(updates) == 0 {
+		var noop string
+		switch {
+		case len(target) > 0:
+			noop = target[0]
+		case len(columns) > 0:
+			noop = columns[0]
+		default:
+			return "", nil
+		}
+		return fmt.Sprintf(" ON DUPLICATE KEY UPDATE %s = %s", noop, noop), nil
+	}
+	sets := make([]string, len(updates))
+	for i, u := range updates {
+		sets[i] = fmt.Sprintf("%s = VALUES(%s)", u, u)
+	}
+	return fmt.Sprintf(" ON DUPLICATE KEY UPDATE %s", strings.Join(sets, ", ")), nil
+}
+
+func (mysql) Lock(mode LockMode) (string, bool) {
+	return lockClause(mode)
+}
+
+type postgresql struct{}
+
+func (postgresql) Placeholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+func (postgresql) Quote(identifier string) string {
+	return fmt.Sprintf(`"%s"`, strings.Replace(identifier, `"`, `""`, -1))
+}
+
+func (postgresql) LimitOffset(limit, offset *int) (string, []interface{}) {
+	return limitOffset(limit, offset)
+}
+
+func (postgresql) Bool(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+func (postgresql) SupportsReturning() bool {
+	return true
+}
+
+func (postgresql) MaxPlaceholders() int {
+	return 65535
+}
+
+func (postgresql) OnConflict(columns, target, updates []string) (string, error) {
+	if len(target) == 0 && len(updates) > 0 {
+		return onConflict(target, updates), ErrConflictTargetRequired
+	}
+	return onConflict(target, updates), nil
+}
+
+func (postgresql) Lock(mode LockMode) (string, bool) {
+	return lockClause(mode)
+}
+
+type oracle struct{}
+
+func (oracle) Placeholder(n int) string {
+	return fmt.Sprintf(":%d", n)
+}
+
+func (oracle) Quote(identifier string) string {
+	return fmt.Sprintf(`"%s"`, strings.Replace(strings.ToUpper(identifier), `"`, `""`, -1))
+}
+
+func (oracle) LimitOffset(limit, offset *int) (string, []interface{}) {
+	var query string
+	var binds []interface{}
+	if offset != nil {
+		query += " OFFSET ? ROWS"
+		binds = append(binds, *offset)
+	}
+	if limit != nil {
+		query += " FETCH NEXT ? ROWS ONLY"
+		binds = append(binds, *limit)
+	}
+	return query, binds
+}
+
+func (oracle) Bool(b bool) string {
+	if b {
+		return "1 = 1"
+	}
+	return "1 = 0"
+}
+
+func (oracle) SupportsReturning() bool {
+	return false
+}
+
+func (oracle) MaxPlaceholders() int {
+	return 65535
+}
+
+func (oracle) OnConflict(columns, target, updates []string) (string, error) {
+	return "", ErrUpsertNotSupported
+}
+
+func (oracle) Lock(mode LockMode) (string, bool) {
+	switch mode {
+	case ForShare, ForShareNoWait, ForShareSkipLocked:
+		return "", false
+	}
+	return lockClause(mode)
+}
+
+func onConflict(target, updates []string) string {
+	query := " ON CONFLICT"
+	if len(target) > 0 {
+		query += fmt.Sprintf(" (%s)", strings.Join(target, ", "))
+	}
+	if len(updates) == 0 {
+		return query + " DO NOTHING"
+	}
+	sets := make([]string, len(updates))
+	for i, u := range updates {
+		sets[i] = fmt.Sprintf("%s = excluded.%s", u, u)
+	}
+	return query + fmt.Sprintf(" DO UPDATE SET %s", strings.Join(sets, ", "))
+}
+
+func buildReturning(d Dialect, columns []string) string {
+	if len(columns) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" RETURNING %s", strings.Join(quoteIdentifiers(d, columns), ", "))
+}
+
+func dialectOf(d Dialect) Dialect {
+	if d == nil {
+		return defaultDialect{}
+	}
+	return d
+}
+
+func limitOffset(l, o *int) (string, []interface{}) {
+	var query string
+	var binds []interface{}
+	if l != nil {
+		q, b := (&limit{*l}).build()
+		query += q
+		binds = append(binds, b...)
+	}
+	if o != nil {
+		q, b := (&offset{*o}).build()
+		query += q
+		binds = append(binds, b...)
+	}
+	return query, binds
+}
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var reservedWords = map[string]bool{}
+
+func init() {
+	for _, w := range strings.Fields(`
+		ADD ALL ALTER AND ANY AS ASC BETWEEN BY CASE CHECK COLUMN CONSTRAINT
+		CREATE CROSS CURRENT DEFAULT DELETE DESC DISTINCT DROP ELSE END EXCEPT
+		EXISTS FETCH FOR FOREIGN FROM FULL GRANT GROUP HAVING IN INDEX INNER
+		INSERT INTERSECT INTO IS JOIN KEY LEFT LEVEL LIKE LIMIT NOT NULL OFFSET
+		ON OR ORDER OUTER PRIMARY RANGE REFERENCES RIGHT ROW ROWS SELECT SET
+		TABLE THEN TO UNION UNIQUE UPDATE USER USING VALUES WHEN WHERE WITH`) {
+		reservedWords[w] = true
+	}
+}
+
+func quoteIdentifier(d Dialect, identifier string) string {
+	parts := strings.Split(identifier, ".")
+	for i, p := range parts {
+		if !identifierPattern.MatchString(p) {
+			return identifier
+		}
+		if reservedWords[strings.ToUpper(p)] {
+			parts[i] = d.Quote(p)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+func quoteIdentifiers(d Dialect, identifiers []string) []string {
+	quoted := make([]string, len(identifiers))
+	for i, identifier := range identifiers {
+		quoted[i] = quoteIdentifier(d, identifier)
+	}
+	return quoted
+}
+
+func rebind(d Dialect, query string) string {
+	if d.Placeholder(1) == "?" {
+		return query
+	}
+	var buf []byte
+	var quote byte
+	n := 0
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '?':
+			n++
+			buf = append(buf, d.Placeholder(n)...)
+			continue
+		}
+		buf = append(buf, c)
+	}
+	return string(buf)
+}
@@ -0,0 +1,195 @@
+package query
+
+import "testing"
+
+func TestDialectPlaceholder(t *testing.T) {
+	s := Select{}
+	s.Dialect(PostgreSQL)
+	s.Table("table")
+	s.Columns("columnA")
+	s.Where("columnA", "value").Where("columnB = '?'").Where("columnC", "valueC")
+	s.Limit(10)
+
+	q, b := s.Build()
+
+	assertQuery(t, `SELECT columnA FROM "table" WHERE columnA = $1 AND columnB = '?' AND columnC = $2 LIMIT $3;`, q)
+	assertBinds(t, []interface{}{"value", "valueC", 10}, b)
+
+	s.Dialect(Oracle)
+
+	q, _ = s.Build()
+
+	assertQuery(t, `SELECT columnA FROM "TABLE" WHERE columnA = :1 AND columnB = '?' AND columnC = :2 FETCH NEXT :3 ROWS ONLY;`, q)
+}
+
+func TestDialectQuote(t *testing.T) {
+	s := Select{}
+	s.Dialect(MySQL)
+	s.Table("order")
+	s.Columns("order.id", "order.key", "COUNT(*)")
+	s.InnerJoin("user", "user.id = order.user_id")
+	s.Where("key", "value")
+	s.GroupBy("order.group")
+	s.OrderBy("desc", "ASC")
+
+	q, b := s.Build()
+
+	assertQuery(t, "SELECT `order`.id, `order`.`key`, COUNT(*) FROM `order` INNER JOIN `user` ON user.id = order.user_id WHERE `key` = ? GROUP BY `order`.`group` ORDER BY `desc` ASC;", q)
+	assertBinds(t, []interface{}{"value"}, b)
+}
+
+func TestDialectLimitOffset(t *testing.T) {
+	for _, tt := range []struct {
+		dialect Dialect
+		limit   bool
+		query   string
+		binds   []interface{}
+	}{
+		{nil, false, "SELECT columnA FROM table LIMIT ? OFFSET ?;", []interface{}{-1, 20}},
+		{SQLite, false, `SELECT columnA FROM "table" LIMIT ? OFFSET ?;`, []interface{}{-1, 20}},
+		{MySQL, false, "SELECT columnA FROM `table` LIMIT 18446744073709551615 OFFSET ?;", []interface{}{20}},
+		{PostgreSQL, false, `SELECT columnA FROM "table" OFFSET $1;`, []interface{}{20}},
+		{Oracle, false, `SELECT columnA FROM "TABLE" OFFSET :1 ROWS;`, []interface{}{20}},
+		{PostgreSQL, true, `SELECT columnA FROM "table" LIMIT $1 OFFSET $2;`, []interface{}{10, 20}},
+		{Oracle, true, `SELECT columnA FROM "TABLE" OFFSET :1 ROWS FETCH NEXT :2 ROWS ONLY;`, []interface{}{20, 10}},
+	} {
+		s := Select{}
+		s.Dialect(tt.dialect)
+		s.Table("table")
+		s.Columns("columnA")
+		s.Offset(20)
+		if tt.limit {
+			s.Limit(10)
+		}
+
+		q, b := s.Build()
+
+		assertQuery(t, tt.query, q)
+		assertBinds(t, tt.binds, b)
+	}
+}
+
+func TestDialectBool(t *testing.T) {
+	for _, tt := range []struct {
+		dialect Dialect
+		query   string
+	}{
+		{nil, "DELETE FROM table WHERE 1 = 0;"},
+		{SQLite, `DELETE FROM "table" WHERE 0;`},
+		{MySQL, "DELETE FROM `table` WHERE FALSE;"},
+		{PostgreSQL, `DELETE FROM "table" WHERE FALSE;`},
+		{Oracle, `DELETE FROM "TABLE" WHERE 1 = 0;`},
+	} {
+		d := Delete{}
+		d.Dialect(tt.dialect)
+		d.Table("table")
+		d.Where("columnA", []int{})
+
+		q, b := d.Build()
+
+		assertQuery(t, tt.query, q)
+		assertEmptyBinds(t, b)
+	}
+}
+
+func TestDialectInsertAndUpdate(t *testing.T) {
+	i := Insert{}
+	i.Dialect(PostgreSQL)
+	i.Table("user")
+	i.Params(map[string]interface{}{"group": "value"})
+
+	q, b := i.Build()
+
+	assertQuery(t, `INSERT INTO "user" ("group") VALUES ($1);`, q)
+	assertBinds(t, []interface{}{"value"}, b)
+
+	u := Update{}
+	u.Dialect(PostgreSQL)
+	u.Table("user")
+	u.Params(map[string]interface{}{"group": "value"})
+	u.Where("id", 1)
+
+	q, b = u.Build()
+
+	assertQuery(t, `UPDATE "user" SET "group" = $1 WHERE id = $2;`, q)
+	assertBinds(t, []interface{}{"value", 1}, b)
+}
+
+func TestDialectLock(t *testing.T) {
+	for _, tt := range []struct {
+		dialect Dialect
+		mode    LockMode
+		query   string
+		err     error
+	}{
+		{nil, ForUpdate, "SELECT columnA FROM table WHERE columnA = ? LIMIT ?;", ErrLockNotSupported},
+		{SQLite, ForUpdate, `SELECT columnA FROM "table" WHERE columnA = ? LIMIT ?;`, ErrLockNotSupported},
+		{MySQL, ForShareNoWait, "SELECT columnA FROM `table` WHERE columnA = ? LIMIT ? FOR SHARE NOWAIT;", nil},
+		{PostgreSQL, ForUpdateSkipLocked, `SELECT columnA FROM "table" WHERE columnA = $1 LIMIT $2 FOR UPDATE SKIP LOCKED;`, nil},
+		{Oracle, ForUpdateNoWait, `SELECT columnA FROM "TABLE" WHERE columnA = :1 FETCH NEXT :2 ROWS ONLY FOR UPDATE NOWAIT;`, nil},
+		{Oracle, ForShare, `SELECT columnA FROM "TABLE" WHERE columnA = :1 FETCH NEXT :2 ROWS ONLY;`, ErrLockNotSupported},
+	} {
+		s := Select{}
+		s.Dialect(tt.dialect)
+		s.Table("table")
+		s.Columns("columnA")
+		s.Where("columnA", "value")
+		s.Limit(1)
+		s.Lock(tt.mode)
+
+		q, b := s.Build()
+
+		assertQuery(t, tt.query, q)
+		assertBinds(t, []interface{}{"value", 1}, b)
+		if err := s.LockError(); err != tt.err {
+			t.Errorf("lock error should be %v, but %v", tt.err, err)
+		}
+	}
+}
+
+func TestDialectOnConflictTarget(t *testing.T) {
+	for _, tt := range []struct {
+		dialect Dialect
+		target  []string
+		query   string
+		err     error
+	}{
+		{PostgreSQL, nil, `INSERT INTO "table" (columnA, columnB) VALUES ($1, $2) ON CONFLICT DO UPDATE SET columnA = excluded.columnA, columnB = excluded.columnB;`, ErrConflictTargetRequired},
+		{PostgreSQL, []string{"columnA"}, `INSERT INTO "table" (columnA, columnB) VALUES ($1, $2) ON CONFLICT (columnA) DO UPDATE SET columnB = excluded.columnB;`, nil},
+		{SQLite, nil, `INSERT INTO "table" (columnA, columnB) VALUES (?, ?) ON CONFLICT DO UPDATE SET columnA = excluded.columnA, columnB = excluded.columnB;`, nil},
+		{MySQL, nil, "INSERT INTO `table` (columnA, columnB) VALUES (?, ?) ON DUPLICATE KEY UPDATE columnA = VALUES(columnA), columnB = VALUES(columnB);", nil},
+		{Oracle, []string{"columnA"}, `INSERT INTO "TABLE" (columnA, columnB) VALUES (:1, :2);`, ErrUpsertNotSupported},
+	} {
+		i := Insert{}
+		i.Dialect(tt.dialect)
+		i.Table("table")
+		i.Params(map[string]interface{}{"columnA": "value1", "columnB": "value2"})
+		i.OnConflict(tt.target...).DoUpdate()
+
+		q, _ := i.Build()
+
+		assertQuery(t, tt.query, q)
+		if err := i.ConflictError(); err != tt.err {
+			t.Errorf("conflict error should be %v, but %v", tt.err, err)
+		}
+	}
+
+	i := Insert{}
+	i.Dialect(PostgreSQL)
+	i.Table("table")
+	i.Params(map[string]interface{}{"columnA": "value1"})
+	i.OnConflict().DoNothing()
+
+	if err := i.ConflictError(); err != nil {
+		t.Errorf("conflict error should be nil, but %v", err)
+	}
+
+	i = Insert{}
+	i.Dialect(MySQL)
+	i.Table("table")
+	i.OnConflict().DoNothing()
+
+	q, _ := i.Build()
+
+	assertQuery(t, "INSERT INTO `table` () VALUES ();", q)
+}
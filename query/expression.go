@@ -0,0 +1,24 @@
+package query
+
+import "fmt"
+
+type Expression struct {
+	sql    string
+	args   []interface{}
+	column string
+}
+
+func Expr(sql string, args ...interface{}) Expression {
+	return Expression{sql: sql, args: args}
+}
+
+func Increment(column string, by interface{}) Expression {
+	return Expression{sql: "%s + ?", args: []interface{}{by}, column: column}
+}
+
+func (e Expression) build(d Dialect) (string, []interface{}) {
+	if e.column != "" {
+		return fmt.Sprintf(e.sql, quoteIdentifier(d, e.column)), e.args
+	}
+	return e.sql, e.args
+}
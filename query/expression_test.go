@@ -0,0 +1,17 @@
+package query
+
+import "testing"
+
+func TestExpression(t *testing.T) {
+	q, b := Expr("columnA + ?", 1).build(defaultDialect{})
+
+	assertQuery(t, "columnA + ?", q)
+	assertBinds(t, []interface{}{1}, b)
+}
+
+func TestIncrementExpression(t *testing.T) {
+	q, b := Increment("order", 1).build(MySQL)
+
+	assertQuery(t, "`order` + ?", q)
+	assertBinds(t, []interface{}{1}, b)
+}
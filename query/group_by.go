@@ -16,6 +16,6 @@ func (g *groupBy) setGroups(group string, groups ...string) {
 	}
 }
 
-func (g *groupBy) build() string {
-	return fmt.Sprintf(" GROUP BY %s", strings.Join(g.queries, ", "))
+func (g *groupBy) build(d Dialect) string {
+	return fmt.Sprintf(" GROUP BY %s", strings.Join(quoteIdentifiers(d, g.queries), ", "))
 }
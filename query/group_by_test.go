@@ -6,7 +6,7 @@ func TestGroupBy(t *testing.T) {
 	g := groupBy{}
 	g.setGroups("columnA", "columnB")
 
-	q := g.build()
+	q := g.build(defaultDialect{})
 
 	assertQuery(t, " GROUP BY columnA, columnB", q)
 }
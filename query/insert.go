@@ -2,12 +2,25 @@ package query
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
 type Insert struct {
-	table  string
-	params map[string]interface{}
+	dialect   Dialect
+	table     string
+	rows      []map[string]interface{}
+	upsert    *upsert
+	returning []string
+}
+
+func (i *Insert) Dialect(d Dialect) *Insert {
+	i.dialect = d
+	return i
+}
+
+func (i *Insert) GetDialect() Dialect {
+	return i.dialect
 }
 
 func (i *Insert) Table(table string) *Insert {
@@ -16,20 +29,117 @@ func (i *Insert) Table(table string) *Insert {
 }
 
 func (i *Insert) Params(params map[string]interface{}) *Insert {
-	i.params = params
+	i.rows = []map[string]interface{}{params}
+	return i
+}
+
+func (i *Insert) Rows(rows ...map[string]interface{}) *Insert {
+	i.rows = rows
+	return i
+}
+
+func (i *Insert) Chunks() []*Insert {
+	columns := i.columns()
+	if len(columns) == 0 {
+		return []*Insert{i}
+	}
+	size := dialectOf(i.dialect).MaxPlaceholders() / len(columns)
+	if size < 1 {
+		size = 1
+	}
+	var chunks []*Insert
+	for start := 0; start < len(i.rows); start += size {
+		end := start + size
+		if end > len(i.rows) {
+			end = len(i.rows)
+		}
+		chunk := *i
+		chunk.rows = i.rows[start:end]
+		chunks = append(chunks, &chunk)
+	}
+	return chunks
+}
+
+func (i *Insert) OnConflict(columns ...string) *Insert {
+	if i.upsert == nil {
+		i.upsert = &upsert{}
+	}
+	i.upsert.setTarget(columns...)
+	return i
+}
+
+func (i *Insert) DoNothing() *Insert {
+	if i.upsert == nil {
+		i.upsert = &upsert{}
+	}
+	i.upsert.setUpdates(false)
+	return i
+}
+
+func (i *Insert) DoUpdate(columns ...string) *Insert {
+	if i.upsert == nil {
+		i.upsert = &upsert{}
+	}
+	i.upsert.setUpdates(true, columns...)
+	return i
+}
+
+func (i *Insert) ConflictError() error {
+	if i.upsert == nil {
+		return nil
+	}
+	_, err := i.upsert.onConflict(dialectOf(i.dialect), i.columns())
+	return err
+}
+
+func (i *Insert) Returning(columns ...string) *Insert {
+	i.returning = columns
 	return i
 }
 
 func (i *Insert) Build() (string, []interface{}) {
-	columns := []string{}
-	ph := []string{}
+	d := dialectOf(i.dialect)
+	columns := i.columns()
+	values := []string{}
 	binds := []interface{}{}
 
-	for k, v := range i.params {
-		columns = append(columns, k)
-		ph = append(ph, "?")
-		binds = append(binds, v)
+	for _, row := range i.rows {
+		ph := make([]string, len(columns))
+		for j, c := range columns {
+			if expr, ok := row[c].(Expression); ok {
+				q, b := expr.build(d)
+				ph[j] = q
+				binds = append(binds, b...)
+				continue
+			}
+			ph[j] = "?"
+			binds = append(binds, row[c])
+		}
+		values = append(values, fmt.Sprintf("(%s)", strings.Join(ph, ", ")))
+	}
+	if len(values) == 0 {
+		values = append(values, "()")
 	}
 
-	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);", i.table, strings.Join(columns, ", "), strings.Join(ph, ", ")), binds
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", quoteIdentifier(d, i.table), strings.Join(quoteIdentifiers(d, columns), ", "), strings.Join(values, ", "))
+	if i.upsert != nil {
+		query += i.upsert.build(d, columns)
+	}
+	query += buildReturning(d, i.returning)
+	return rebind(d, query+";"), binds
+}
+
+func (i *Insert) columns() []string {
+	seen := map[string]bool{}
+	columns := []string{}
+	for _, row := range i.rows {
+		for k := range row {
+			if !seen[k] {
+				seen[k] = true
+				columns = append(columns, k)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
 }
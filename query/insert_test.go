@@ -2,7 +2,7 @@ package query
 
 import "testing"
 
-func testInsert(t *testing.T) {
+func TestInsert(t *testing.T) {
 	insert := Insert{}
 	insert.Table("table")
 	insert.Params(map[string]interface{}{
@@ -12,5 +12,85 @@ func testInsert(t *testing.T) {
 	q, b := insert.Build()
 
 	assertQuery(t, "INSERT INTO table (columnA, columnB) VALUES (?, ?);", q)
-	assertBinds(t, []interface{}{1}, b)
+	assertBinds(t, []interface{}{"value1", "value2"}, b)
+}
+
+func TestInsertReturning(t *testing.T) {
+	insert := Insert{}
+	insert.Dialect(PostgreSQL)
+	insert.Table("table")
+	insert.Params(map[string]interface{}{
+		"columnA": "value1",
+	})
+	insert.Returning("id", "columnA")
+	q, b := insert.Build()
+
+	assertQuery(t, `INSERT INTO "table" (columnA) VALUES ($1) RETURNING id, columnA;`, q)
+	assertBinds(t, []interface{}{"value1"}, b)
+}
+
+func TestInsertOnConflict(t *testing.T) {
+	insert := Insert{}
+	insert.Table("table")
+	insert.Params(map[string]interface{}{
+		"columnA": "value1",
+	})
+	insert.OnConflict("columnA").DoUpdate()
+	q, b := insert.Build()
+
+	assertQuery(t, "INSERT INTO table (columnA) VALUES (?) ON CONFLICT (columnA) DO NOTHING;", q)
+	assertBinds(t, []interface{}{"value1"}, b)
+
+	insert.Dialect(MySQL).DoUpdate("columnA")
+	q, _ = insert.Build()
+
+	assertQuery(t, "INSERT INTO `table` (columnA) VALUES (?) ON DUPLICATE KEY UPDATE columnA = VALUES(columnA);", q)
+}
+
+func TestInsertRows(t *testing.T) {
+	insert := Insert{}
+	insert.Table("table")
+	insert.Rows(
+		map[string]interface{}{"columnA": "value1"},
+		map[string]interface{}{"columnA": "value2"},
+		map[string]interface{}{"columnB": "value3"},
+	)
+	q, b := insert.Build()
+
+	assertQuery(t, "INSERT INTO table (columnA, columnB) VALUES (?, ?), (?, ?), (?, ?);", q)
+	assertBinds(t, []interface{}{"value1", nil, "value2", nil, nil, "value3"}, b)
+}
+
+func TestInsertChunks(t *testing.T) {
+	rows := make([]map[string]interface{}, 1000)
+	for i := range rows {
+		rows[i] = map[string]interface{}{"columnA": i}
+	}
+	insert := Insert{}
+	insert.Table("table")
+	insert.Rows(rows...)
+
+	chunks := insert.Chunks()
+
+	if len(chunks) != 2 {
+		t.Fatalf("chunk count should be 2, but %v", len(chunks))
+	}
+	if _, b := chunks[0].Build(); len(b) != 999 {
+		t.Errorf("bind count should be 999, but %v", len(b))
+	}
+	if _, b := chunks[1].Build(); len(b) != 1 || b[0] != 999 {
+		t.Errorf("binds should be [999], but %v", b)
+	}
+}
+
+func TestInsertExpression(t *testing.T) {
+	insert := Insert{}
+	insert.Table("table")
+	insert.Params(map[string]interface{}{
+		"columnA": Expr("CURRENT_TIMESTAMP"),
+	})
+	q, b := insert.Build()
+
+	assertQuery(t, "INSERT INTO table (columnA) VALUES (CURRENT_TIMESTAMP);", q)
+	assertEmptyBinds(t, b)
 }
@@ -6,6 +6,8 @@ type join struct {
 	table string
 	typ   string
 	on    *condition
+	raw   string
+	args  []interface{}
 }
 
 func innerJoin(table string, cond string, args ...interface{}) *join {
@@ -13,6 +15,29 @@ func innerJoin(table string, cond string, args ...interface{}) *join {
 	return j.setJoin("INNER", table, cond, args...)
 }
 
+func leftJoin(table string, cond string, args ...interface{}) *join {
+	j := &join{}
+	return j.setJoin("LEFT", table, cond, args...)
+}
+
+func rightJoin(table string, cond string, args ...interface{}) *join {
+	j := &join{}
+	return j.setJoin("RIGHT", table, cond, args...)
+}
+
+func fullJoin(table string, cond string, args ...interface{}) *join {
+	j := &join{}
+	return j.setJoin("FULL OUTER", table, cond, args...)
+}
+
+func crossJoin(table string) *join {
+	return &join{typ: "CROSS", table: table}
+}
+
+func rawJoin(sql string, args ...interface{}) *join {
+	return &join{raw: sql, args: args}
+}
+
 func (j *join) setJoin(typ string, table string, cond string, args ...interface{}) *join {
 	j.typ = typ
 	j.table = table
@@ -23,8 +48,14 @@ func (j *join) setJoin(typ string, table string, cond string, args ...interface{
 	return j
 }
 
-func (j *join) build() (string, []interface{}) {
-	baseQuery := fmt.Sprintf(" %s JOIN %s", j.typ, j.table)
-	onQuery, onBinds := j.on.build()
+func (j *join) build(d Dialect) (string, []interface{}) {
+	if j.raw != "" {
+		return " " + j.raw, j.args
+	}
+	baseQuery := fmt.Sprintf(" %s JOIN %s", j.typ, quoteIdentifier(d, j.table))
+	if j.on == nil {
+		return baseQuery, nil
+	}
+	onQuery, onBinds := j.on.build(d)
 	return baseQuery + onQuery, onBinds
 }
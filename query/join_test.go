@@ -4,8 +4,45 @@ import "testing"
 
 func TestInnerJoin(t *testing.T) {
 	j := innerJoin("table", "columnA", "value")
-	q, b := j.build()
+	q, b := j.build(defaultDialect{})
 
 	assertQuery(t, " INNER JOIN table ON columnA = ?", q)
 	assertBinds(t, []interface{}{"value"}, b)
 }
+
+func TestInnerJoinWithIn(t *testing.T) {
+	j := innerJoin("table", "columnA", []int{1, 2})
+	q, b := j.build(defaultDialect{})
+
+	assertQuery(t, " INNER JOIN table ON columnA IN (?, ?)", q)
+	assertBinds(t, []interface{}{1, 2}, b)
+}
+
+func TestOuterJoin(t *testing.T) {
+	for typ, j := range map[string]*join{
+		"LEFT":       leftJoin("table", "columnA", "value"),
+		"RIGHT":      rightJoin("table", "columnA", "value"),
+		"FULL OUTER": fullJoin("table", "columnA", "value"),
+	} {
+		q, b := j.build(defaultDialect{})
+
+		assertQuery(t, " "+typ+" JOIN table ON columnA = ?", q)
+		assertBinds(t, []interface{}{"value"}, b)
+	}
+}
+
+func TestCrossJoin(t *testing.T) {
+	j := crossJoin("table")
+	q, b := j.build(defaultDialect{})
+
+	assertQuery(t, " CROSS JOIN table", q)
+	assertEmptyBinds(t, b)
+}
+
+func TestRawJoin(t *testing.T) {
+	j := rawJoin("LEFT JOIN table ON table.id = columnA AND table.columnB = ?", "value")
+	q, b := j.build(defaultDialect{})
+
+	assertQuery(t, " LEFT JOIN table ON table.id = columnA AND table.columnB = ?", q)
+	assertBinds(t, []interface{}{"value"}, b)
+}
@@ -0,0 +1,29 @@
+package query
+
+import "strings"
+
+const likeEscape = "!"
+
+var likeReplacer = strings.NewReplacer(
+	likeEscape, likeEscape+likeEscape,
+	"%", likeEscape+"%",
+	"_", likeEscape+"_",
+)
+
+type Like string
+
+func EscapeLike(s string) string {
+	return likeReplacer.Replace(s)
+}
+
+func Contains(s string) Like {
+	return Like("%" + EscapeLike(s) + "%")
+}
+
+func StartsWith(s string) Like {
+	return Like(EscapeLike(s) + "%")
+}
+
+func EndsWith(s string) Like {
+	return Like("%" + EscapeLike(s))
+}
@@ -0,0 +1,13 @@
+package query
+
+import "testing"
+
+func TestEscapeLike(t *testing.T) {
+	assertQuery(t, "100!% !_a!!", EscapeLike("100% _a!"))
+}
+
+func TestLikeHelpers(t *testing.T) {
+	assertQuery(t, "%50!%%", string(Contains("50%")))
+	assertQuery(t, "50!%%", string(StartsWith("50%")))
+	assertQuery(t, "%50!%", string(EndsWith("50%")))
+}
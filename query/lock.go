@@ -0,0 +1,20 @@
+package query
+
+import "errors"
+
+type LockMode string
+
+const (
+	ForUpdate           LockMode = "FOR UPDATE"
+	ForUpdateNoWait     LockMode = "FOR UPDATE NOWAIT"
+	ForUpdateSkipLocked LockMode = "FOR UPDATE SKIP LOCKED"
+	ForShare            LockMode = "FOR SHARE"
+	ForShareNoWait      LockMode = "FOR SHARE NOWAIT"
+	ForShareSkipLocked  LockMode = "FOR SHARE SKIP LOCKED"
+)
+
+var ErrLockNotSupported = errors.New("lock mode is not supported by the dialect")
+
+func lockClause(mode LockMode) (string, bool) {
+	return " " + string(mode), true
+}
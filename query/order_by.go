@@ -19,18 +19,28 @@ type order struct {
 	sort   string
 }
 
-func (o order) build() string {
-	return fmt.Sprintf("%s %s", o.column, o.sort)
+func (o order) build(d Dialect) string {
+	return fmt.Sprintf("%s %s", quoteIdentifier(d, o.column), o.sort)
 }
 
 func (o *orderBy) addOrder(column, sort string) {
 	o.orders = append(o.orders, order{column, sort})
 }
 
-func (o *orderBy) build() string {
+func (o *orderBy) reverse() {
+	for i, order := range o.orders {
+		if strings.ToUpper(order.sort) == DESC {
+			o.orders[i].sort = ASC
+		} else {
+			o.orders[i].sort = DESC
+		}
+	}
+}
+
+func (o *orderBy) build(d Dialect) string {
 	queries := []string{}
 	for _, o := range o.orders {
-		queries = append(queries, o.build())
+		queries = append(queries, o.build(d))
 	}
 	return fmt.Sprintf(" ORDER BY %s", strings.Join(queries, ", "))
 }
@@ -7,7 +7,7 @@ func TestOrderBy(t *testing.T) {
 	o.addOrder("columnA", ASC)
 	o.addOrder("columnB", DESC)
 
-	q := o.build()
+	q := o.build(defaultDialect{})
 
 	assertQuery(t, " ORDER BY columnA ASC, columnB DESC", q)
 }
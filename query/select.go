@@ -6,20 +6,99 @@ import (
 )
 
 type Select struct {
-	table   string
-	columns []string
-	orderBy *orderBy
-	limit   *limit
-	offset  *offset
-	groupBy *groupBy
-	where   *condition
-	having  *condition
-	joins   []*join
-	explain bool
+	dialect   Dialect
+	with      *with
+	table     string
+	alias     string
+	from      subquery
+	columns   []string
+	orderBy   *orderBy
+	limit     *limit
+	offset    *offset
+	groupBy   *groupBy
+	where     *condition
+	scope     *condition
+	having    *condition
+	joins     []*join
+	compounds []*compound
+	lock      LockMode
+	explain   bool
+}
+
+func (s *Select) With(name string, query interface{}) *Select {
+	return s.addWith(name, query, false)
+}
+
+func (s *Select) WithRecursive(name string, query interface{}) *Select {
+	return s.addWith(name, query, true)
+}
+
+func (s *Select) addWith(name string, query interface{}, recursive bool) *Select {
+	if s.with == nil {
+		s.with = &with{}
+	}
+	s.with.addCte(name, query, recursive)
+	return s
+}
+
+func (s *Select) Clone() *Select {
+	c := *s
+	c.columns = append([]string(nil), s.columns...)
+	c.joins = append([]*join(nil), s.joins...)
+	c.compounds = append([]*compound(nil), s.compounds...)
+	if s.with != nil {
+		c.with = &with{ctes: append([]*cte(nil), s.with.ctes...)}
+	}
+	if s.orderBy != nil {
+		c.orderBy = &orderBy{orders: append([]order(nil), s.orderBy.orders...)}
+	}
+	if s.limit != nil {
+		c.limit = &limit{s.limit.limit}
+	}
+	if s.offset != nil {
+		c.offset = &offset{s.offset.offset}
+	}
+	if s.groupBy != nil {
+		c.groupBy = &groupBy{queries: append([]string(nil), s.groupBy.queries...)}
+	}
+	if s.where != nil {
+		c.where = s.where.clone()
+	}
+	if s.scope != nil {
+		c.scope = s.scope.clone()
+	}
+	if s.having != nil {
+		c.having = s.having.clone()
+	}
+	return &c
+}
+
+func (s *Select) Dialect(d Dialect) *Select {
+	s.dialect = d
+	return s
+}
+
+func (s *Select) GetDialect() Dialect {
+	return s.dialect
 }
 
 func (s *Select) Table(table string) *Select {
 	s.table = table
+	s.alias = ""
+	s.from = nil
+	return s
+}
+
+func (s *Select) From(source interface{}, alias string) *Select {
+	switch source := source.(type) {
+	case string:
+		s.table = source
+		s.alias = alias
+		s.from = nil
+	case subquery:
+		s.alias = alias
+		s.from = source
+	}
 	return s
 }
 
@@ -32,16 +111,87 @@ func (s *Select) GetColumns() []string {
 	return s.columns
 }
 
-func (s *Select) Where(cond string, args ...interface{}) *Select {
+func (s *Select) Where(cond interface{}, args ...interface{}) *Select {
+	return s.addWhere(AND, false, cond, args...)
+}
+
+func (s *Select) And(cond interface{}, args ...interface{}) *Select {
+	return s.Where(cond, args...)
+}
+
+func (s *Select) Or(cond interface{}, args ...interface{}) *Select {
+	return s.addWhere(OR, false, cond, args...)
+}
+
+func (s *Select) Not(cond interface{}, args ...interface{}) *Select {
+	return s.addWhere(AND, true, cond, args...)
+}
+
+func (s *Select) addWhere(conj string, not bool, cond interface{}, args ...interface{}) *Select {
 	if s.where == nil {
 		s.where = &condition{phrase: "WHERE"}
 	}
-	s.where.addExpression(cond, args...)
+	s.where.add(conj, not, cond, args...)
 	return s
 }
 
-func (s *Select) And(cond string, args ...interface{}) *Select {
-	return s.Where(cond, args...)
+func (s *Select) Scope(cond interface{}, args ...interface{}) *Select {
+	if s.scope == nil {
+		s.scope = &condition{phrase: "WHERE"}
+	}
+	s.scope.add(AND, false, cond, args...)
+	return s
+}
+
+func (s *Select) ScopeWhere() *Select {
+	if !s.where.isEmpty() {
+		s.Scope(&Group{where: s.where})
+	}
+	s.where = nil
+	return s
+}
+
+func (s *Select) UnscopeWhere(columns ...string) *Select {
+	s.where.remove(columns...)
+	s.scope.removeAll(columns...)
+	return s
+}
+
+func (s *Select) WhereExists(sub interface{}) *Select {
+	return s.addExists(false, sub)
+}
+
+func (s *Select) WhereNotExists(sub interface{}) *Select {
+	return s.addExists(true, sub)
+}
+
+func (s *Select) addExists(not bool, sub interface{}) *Select {
+	if s.where == nil {
+		s.where = &condition{phrase: "WHERE"}
+	}
+	s.where.addExists(AND, not, sub)
+	return s
+}
+
+func (s *Select) ConditionError() error {
+	for _, c := range []*condition{s.scope, s.where, s.having} {
+		if err := c.error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Select) whereCondition() *condition {
+	if s.scope.isEmpty() {
+		return s.where
+	}
+	if s.where.isEmpty() {
+		return s.scope
+	}
+	c := s.scope.clone()
+	c.add(AND, false, &Group{where: s.where})
+	return c
 }
 
 func (s *Select) OrderBy(column, order string) *Select {
@@ -89,16 +239,194 @@ func (s *Select) InnerJoin(table string, cond string, args ...interface{}) *Sele
 	return s
 }
 
+func (s *Select) LeftJoin(table string, cond string, args ...interface{}) *Select {
+	s.joins = append(s.joins, leftJoin(table, cond, args...))
+	return s
+}
+
+func (s *Select) RightJoin(table string, cond string, args ...interface{}) *Select {
+	s.joins = append(s.joins, rightJoin(table, cond, args...))
+	return s
+}
+
+func (s *Select) FullJoin(table string, cond string, args ...interface{}) *Select {
+	s.joins = append(s.joins, fullJoin(table, cond, args...))
+	return s
+}
+
+func (s *Select) CrossJoin(table string) *Select {
+	s.joins = append(s.joins, crossJoin(table))
+	return s
+}
+
+func (s *Select) Joins(sql string, args ...interface{}) *Select {
+	s.joins = append(s.joins, rawJoin(sql, args...))
+	return s
+}
+
+func (s *Select) Union(query interface{}) *Select {
+	return s.addCompound("UNION", query)
+}
+
+func (s *Select) UnionAll(query interface{}) *Select {
+	return s.addCompound("UNION ALL", query)
+}
+
+func (s *Select) Intersect(query interface{}) *Select {
+	return s.addCompound("INTERSECT", query)
+}
+
+func (s *Select) Except(query interface{}) *Select {
+	return s.addCompound("EXCEPT", query)
+}
+
+func (s *Select) addCompound(operator string, query interface{}) *Select {
+	if c := newCompound(operator, query); c != nil {
+		s.compounds = append(s.compounds, c)
+	}
+	return s
+}
+
+func (s *Select) Lock(mode LockMode) *Select {
+	s.lock = mode
+	return s
+}
+
+func (s *Select) GetLock() LockMode {
+	return s.lock
+}
+
+func (s *Select) LockError() error {
+	if s.lock == "" {
+		return nil
+	}
+	if _, ok := dialectOf(s.dialect).Lock(s.lock); !ok {
+		return ErrLockNotSupported
+	}
+	return nil
+}
+
+func (s *Select) Unscope(clauses ...Clause) *Select {
+	for _, c := range clauses {
+		switch c {
+		case WhereClause:
+			s.where = nil
+			s.scope = nil
+		case OrderClause:
+			s.orderBy = nil
+		case LimitClause:
+			s.limit = nil
+		case OffsetClause:
+			s.offset = nil
+		case GroupClause:
+			s.groupBy = nil
+		case HavingClause:
+			s.having = nil
+		case JoinsClause:
+			s.joins = nil
+		case LockClause:
+			s.lock = ""
+		}
+	}
+	return s
+}
+
+func (s *Select) HasClause(clause Clause) bool {
+	switch clause {
+	case WhereClause:
+		return !s.where.isEmpty() || !s.scope.isEmpty()
+	case OrderClause:
+		return s.orderBy != nil && len(s.orderBy.orders) > 0
+	case LimitClause:
+		return s.limit != nil
+	case OffsetClause:
+		return s.offset != nil
+	case GroupClause:
+		return s.groupBy != nil
+	case HavingClause:
+		return !s.having.isEmpty()
+	case JoinsClause:
+		return len(s.joins) > 0
+	case LockClause:
+		return s.lock != ""
+	}
+	return false
+}
+
+func (s *Select) Rewhere(cond string, args ...interface{}) *Select {
+	return s.UnscopeWhere(cond).Where(cond, args...)
+}
+
+func (s *Select) Reorder(column, order string) *Select {
+	return s.Unscope(OrderClause).OrderBy(column, order)
+}
+
+func (s *Select) ReverseOrder() *Select {
+	if s.orderBy != nil {
+		s.orderBy.reverse()
+	}
+	return s
+}
+
+func (s *Select) IsCompound() bool {
+	return len(s.compounds) > 0
+}
+
+func (s *Select) isSorted() bool {
+	return s.orderBy != nil || s.limit != nil || s.offset != nil
+}
+
+func (s *Select) isSimple() bool {
+	return s.from == nil && s.with == nil && s.alias == "" && len(s.joins) == 0 && len(s.compounds) == 0 &&
+		s.groupBy == nil && s.having == nil && s.limit == nil && s.offset == nil
+}
+
+func (s *Select) primaryKeys(pk string) *Select {
+	sub := s.Clone()
+	sub.explain = false
+	sub.lock = ""
+	sub.columns = []string{fmt.Sprintf("%s.%s", s.table, pk)}
+	if s.alias != "" {
+		sub.columns = []string{fmt.Sprintf("%s.%s", s.alias, pk)}
+	}
+	return (&Select{}).From(sub, "t").Columns(pk)
+}
+
 func (s *Select) Explain() *Select {
 	s.explain = true
 	return s
 }
 
 func (s *Select) Build() (string, []interface{}) {
+	d := dialectOf(s.dialect)
+	query, binds := s.build(d)
+	return rebind(d, query+";"), binds
+}
+
+func (s *Select) buildSubquery(d Dialect) (string, []interface{}) {
+	return s.build(d)
+}
+
+func (s *Select) build(d Dialect) (string, []interface{}) {
 
 	var binds []interface{}
+	var withQuery string
+
+	if s.with != nil {
+		withQuery, binds = s.with.build(d)
+	}
+
+	table := quoteIdentifier(d, s.table)
+	if s.from != nil {
+		q, b := s.from.buildSubquery(d)
+		table = fmt.Sprintf("(%s)", q)
+		binds = append(binds, b...)
+	}
+	if s.alias != "" {
+		table = fmt.Sprintf("%s AS %s", table, quoteIdentifier(d, s.alias))
+	}
 
-	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(s.columns, ", "), s.table)
+	query := fmt.Sprintf("%sSELECT %s FROM %s", withQuery, strings.Join(quoteIdentifiers(d, s.columns), ", "), table)
 
 	if s.explain {
 		explain := "EXPLAIN "
@@ -107,53 +435,59 @@ func (s *Select) Build() (string, []interface{}) {
 
 	var joinQuery string
 	for _, j := range s.joins {
-		q, b := j.build()
+		q, b := j.build(d)
 		joinQuery += q
 		binds = append(binds, b...)
 	}
 	query += joinQuery
 
-	if s.where != nil {
-		q, b := s.where.build()
+	if where := s.whereCondition(); !where.isEmpty() {
+		q, b := where.build(d)
 		query += q
 		binds = append(binds, b...)
 	}
 
 	if s.groupBy != nil {
-		q := s.groupBy.build()
+		q := s.groupBy.build(d)
 		query += q
 	}
 
 	if s.having != nil {
-		q, b := s.having.build()
+		q, b := s.having.build(d)
+		query += q
+		binds = append(binds, b...)
+	}
+
+	for _, c := range s.compounds {
+		q, b := c.build(d)
 		query += q
 		binds = append(binds, b...)
 	}
 
 	if s.orderBy != nil {
-		q := s.orderBy.build()
+		q := s.orderBy.build(d)
 		query += q
 	}
 
-	if s.limit != nil {
-		q, b := s.limit.build()
+	if s.limit != nil || s.offset != nil {
+		var l, o *int
+		if s.limit != nil {
+			l = &s.limit.limit
+		}
+		if s.offset != nil {
+			o = &s.offset.offset
+		}
+		q, b := d.LimitOffset(l, o)
 		query += q
 		binds = append(binds, b...)
 	}
 
-	if s.offset != nil {
-		if s.limit == nil {
-			limit := limit{}
-			limit.setLimit(-1)
-			q, b := limit.build()
+	if s.lock != "" {
+		if q, ok := d.Lock(s.lock); ok {
 			query += q
-			binds = append(binds, b...)
 		}
-		q, b := s.offset.build()
-		query += q
-		binds = append(binds, b...)
 	}
 
-	return query + ";", binds
+	return query, binds
 
 }
@@ -36,6 +36,87 @@ func TestSelectInnerJoin(t *testing.T) {
 	assertEmptyBinds(t, b)
 }
 
+func TestSelectLeftJoinAndJoins(t *testing.T) {
+	s := Select{}
+	s.Table("table")
+	s.Columns("columnA", "columnB")
+	s.LeftJoin("tableB", "id = tableB.table_id")
+	s.Joins("INNER JOIN tableC ON tableC.id = tableB.c_id AND tableC.columnC = ?", "value")
+
+	q, b := s.Build()
+
+	assertQuery(t, "SELECT columnA, columnB FROM table LEFT JOIN tableB ON id = tableB.table_id INNER JOIN tableC ON tableC.id = tableB.c_id AND tableC.columnC = ?;", q)
+	assertBinds(t, []interface{}{"value"}, b)
+}
+
+func TestSelectFromSubquery(t *testing.T) {
+	sub := &Select{}
+	sub.Table("table").Columns("columnA", "COUNT(*) AS cnt").Where("columnB", "value").GroupBy("columnA")
+
+	s := Select{}
+	s.From(sub, "t")
+	s.Columns("columnA")
+	s.Where("cnt", ">", 1)
+
+	q, b := s.Build()
+
+	assertQuery(t, "SELECT columnA FROM (SELECT columnA, COUNT(*) AS cnt FROM table WHERE columnB = ? GROUP BY columnA) AS t WHERE cnt > ?;", q)
+	assertBinds(t, []interface{}{"value", 1}, b)
+}
+
+func TestSelectWhereExists(t *testing.T) {
+	sub := &Select{}
+	sub.Table("tableB").Columns("1").Where("tableB.table_id = table.id")
+
+	s := Select{}
+	s.Table("table")
+	s.Columns("columnA")
+	s.Where("columnA", "value")
+	s.WhereNotExists(sub)
+
+	q, b := s.Build()
+
+	assertQuery(t, "SELECT columnA FROM table WHERE columnA = ? AND NOT EXISTS (SELECT 1 FROM tableB WHERE tableB.table_id = table.id);", q)
+	assertBinds(t, []interface{}{"value"}, b)
+}
+
+func TestSelectCompound(t *testing.T) {
+	union := &Select{}
+	union.Table("tableB").Columns("columnA", "columnB").Where("columnA", "valueB")
+
+	except := &Select{}
+	except.Table("tableC").Columns("columnA", "columnB")
+
+	s := Select{}
+	s.Table("table")
+	s.Columns("columnA", "columnB")
+	s.Where("columnA", "value")
+	s.UnionAll(union).Except(except)
+	s.OrderBy("columnA", "DESC").Limit(10).Offset(20)
+
+	q, b := s.Build()
+
+	assertQuery(t, "SELECT columnA, columnB FROM table WHERE columnA = ? UNION ALL SELECT columnA, columnB FROM tableB WHERE columnA = ? EXCEPT SELECT columnA, columnB FROM tableC ORDER BY columnA DESC LIMIT ? OFFSET ?;", q)
+	assertBinds(t, []interface{}{"value", "valueB", 10, 20}, b)
+}
+
+func TestSelectWith(t *testing.T) {
+	cte := &Select{}
+	cte.Table("tableB").Columns("table_id").Where("columnB", "valueB")
+
+	s := Select{}
+	s.With("cte", cte)
+	s.Table("table")
+	s.Columns("columnA")
+	s.InnerJoin("cte", "cte.table_id = table.id")
+	s.Where("columnA", "value")
+
+	q, b := s.Explain().Build()
+
+	assertQuery(t, "EXPLAIN WITH cte AS (SELECT table_id FROM tableB WHERE columnB = ?) SELECT columnA FROM table INNER JOIN cte ON cte.table_id = table.id WHERE columnA = ?;", q)
+	assertBinds(t, []interface{}{"valueB", "value"}, b)
+}
+
 func TestSelectWhere(t *testing.T) {
 	s := Select{}
 	s.Table("table")
@@ -48,6 +129,194 @@ func TestSelectWhere(t *testing.T) {
 	assertBinds(t, []interface{}{"value"}, b)
 }
 
+func TestSelectUnscopeWhere(t *testing.T) {
+	s := Select{}
+	s.Table("table")
+	s.Columns("columnA", "columnB")
+	s.Where("table.deleted_at", nil).Where("columnA", "value").Where("table.deleted_at", "IS NOT", nil)
+	s.UnscopeWhere("table.deleted_at")
+
+	q, b := s.Build()
+
+	assertQuery(t, "SELECT columnA, columnB FROM table WHERE columnA = ?;", q)
+	assertBinds(t, []interface{}{"value"}, b)
+
+	s.UnscopeWhere("columnA")
+
+	q, b = s.Build()
+
+	assertQuery(t, "SELECT columnA, columnB FROM table;", q)
+	assertBinds(t, nil, b)
+}
+
+func TestSelectScope(t *testing.T) {
+	s := Select{}
+	s.Table("table")
+	s.Columns("columnA")
+	s.Where("columnB", "value1").Or("columnB", "value2").ScopeWhere()
+	s.Scope("table.deleted_at", nil)
+	s.Where("columnA", "value3").Or("columnA", "value4")
+
+	q, b := s.Build()
+
+	assertQuery(t, "SELECT columnA FROM table WHERE (columnB = ? OR columnB = ?) AND table.deleted_at IS NULL AND (columnA = ? OR columnA = ?);", q)
+	assertBinds(t, []interface{}{"value1", "value2", "value3", "value4"}, b)
+
+	s.Rewhere("table.deleted_at", "IS NOT", nil)
+
+	q, _ = s.Build()
+
+	assertQuery(t, "SELECT columnA FROM table WHERE (columnB = ? OR columnB = ?) AND ((columnA = ? OR columnA = ?) AND table.deleted_at IS NOT NULL);", q)
+
+	s.Unscope(WhereClause)
+
+	if s.HasClause(WhereClause) {
+		t.Errorf("where clause should be removed")
+	}
+}
+
+func TestSelectRewhereScope(t *testing.T) {
+	s := Select{}
+	s.Table("table")
+	s.Columns("columnA")
+	s.Where("columnA", ">", 18).ScopeWhere()
+	s.Scope("table.deleted_at", nil)
+	s.Rewhere("columnA", "<", 10)
+
+	q, b := s.Build()
+
+	assertQuery(t, "SELECT columnA FROM table WHERE table.deleted_at IS NULL AND columnA < ?;", q)
+	assertBinds(t, []interface{}{10}, b)
+
+	s = Select{}
+	s.Table("table")
+	s.Columns("columnA")
+	s.Where("columnA", ">", 18).Or("columnB", "value").ScopeWhere()
+	s.UnscopeWhere("columnA")
+
+	q, b = s.Build()
+
+	assertQuery(t, "SELECT columnA FROM table WHERE columnB = ?;", q)
+	assertBinds(t, []interface{}{"value"}, b)
+}
+
+func TestSelectUnscope(t *testing.T) {
+	s := Select{}
+	s.Table("table")
+	s.Columns("columnA")
+	s.InnerJoin("other", "other.id = table.other_id")
+	s.Where("columnA", "value").OrderBy("columnA", "ASC").Limit(10).Offset(20).Lock(ForUpdate)
+	s.Unscope(JoinsClause, LimitClause, OffsetClause, LockClause)
+
+	for _, c := range []Clause{JoinsClause, LimitClause, OffsetClause, LockClause, GroupClause, HavingClause} {
+		if s.HasClause(c) {
+			t.Errorf("%s clause should be removed", c)
+		}
+	}
+
+	q, b := s.Build()
+
+	assertQuery(t, "SELECT columnA FROM table WHERE columnA = ? ORDER BY columnA ASC;", q)
+	assertBinds(t, []interface{}{"value"}, b)
+
+	s.Unscope(WhereClause, OrderClause)
+
+	q, b = s.Build()
+
+	assertQuery(t, "SELECT columnA FROM table;", q)
+	assertBinds(t, nil, b)
+}
+
+func TestSelectRewhereAndReorder(t *testing.T) {
+	s := Select{}
+	s.Table("table")
+	s.Columns("columnA")
+	s.Where("columnA", "value1").Where("columnB", "value2").OrderBy("columnA", "ASC").OrderBy("columnB", "desc")
+	s.Rewhere("columnA", ">", "value3").ReverseOrder()
+
+	q, b := s.Build()
+
+	assertQuery(t, "SELECT columnA FROM table WHERE columnB = ? AND columnA > ? ORDER BY columnA DESC, columnB ASC;", q)
+	assertBinds(t, []interface{}{"value2", "value3"}, b)
+
+	s.Reorder("columnB", "ASC")
+
+	q, _ = s.Build()
+
+	assertQuery(t, "SELECT columnA FROM table WHERE columnB = ? AND columnA > ? ORDER BY columnB ASC;", q)
+}
+
+func TestSelectClone(t *testing.T) {
+	base := Select{}
+	base.Table("table")
+	base.Columns("columnA")
+	base.InnerJoin("other", "other.id = table.other_id")
+	base.Where("columnA", "value1").OrderBy("columnA", "ASC").GroupBy("columnA").Having("COUNT(*) > 1").Limit(10)
+
+	c := base.Clone()
+	c.Columns("COUNT(*)").Where("columnB", "value2").OrderBy("columnB", "DESC").GroupBy("columnB").Having("COUNT(*) < 5").Limit(1).Offset(2)
+	c.LeftJoin("another", "another.id = table.another_id").ReverseOrder()
+
+	q, b := base.Build()
+
+	assertQuery(t, "SELECT columnA FROM table INNER JOIN other ON other.id = table.other_id WHERE columnA = ? GROUP BY columnA HAVING COUNT(*) > 1 ORDER BY columnA ASC LIMIT ?;", q)
+	assertBinds(t, []interface{}{"value1", 10}, b)
+
+	q, b = c.Build()
+
+	assertQuery(t, "SELECT COUNT(*) FROM table INNER JOIN other ON other.id = table.other_id LEFT JOIN another ON another.id = table.another_id WHERE columnA = ? AND columnB = ? GROUP BY columnA, columnB HAVING COUNT(*) > 1 AND COUNT(*) < 5 ORDER BY columnA DESC, columnB ASC LIMIT ? OFFSET ?;", q)
+	assertBinds(t, []interface{}{"value1", "value2", 1, 2}, b)
+}
+
+func TestSelectCloneConcurrently(t *testing.T) {
+	base := Select{}
+	base.Table("table")
+	base.Columns("columnA")
+	base.Where("columnA", "value")
+
+	done := make(chan string)
+	for i := 0; i < 8; i++ {
+		go func(i int) {
+			q, _ := base.Clone().Limit(i).Build()
+			done <- q
+		}(i)
+	}
+	for i := 0; i < 8; i++ {
+		assertQuery(t, "SELECT columnA FROM table WHERE columnA = ? LIMIT ?;", <-done)
+	}
+
+	q, _ := base.Build()
+
+	assertQuery(t, "SELECT columnA FROM table WHERE columnA = ?;", q)
+}
+
+func TestSelectOrAndNot(t *testing.T) {
+	s := Select{}
+	s.Table("table")
+	s.Columns("columnA", "columnB")
+	s.Where("columnA", "value1").Or("columnB", "value2").Not("columnC", "value3")
+
+	q, b := s.Build()
+
+	assertQuery(t, "SELECT columnA, columnB FROM table WHERE (columnA = ? OR columnB = ?) AND NOT (columnC = ?);", q)
+	assertBinds(t, []interface{}{"value1", "value2", "value3"}, b)
+}
+
+func TestSelectOrSelect(t *testing.T) {
+	s1 := Select{}
+	s1.Table("table")
+	s1.Columns("columnA", "columnB")
+	s1.Where("columnA", "value1").And("columnB", "value2")
+
+	s2 := Select{}
+	s2.Where("columnA", "value3").And("columnB", "value4")
+
+	q, b := s1.Or(&s2).Build()
+
+	assertQuery(t, "SELECT columnA, columnB FROM table WHERE (columnA = ? AND columnB = ?) OR (columnA = ? AND columnB = ?);", q)
+	assertBinds(t, []interface{}{"value1", "value2", "value3", "value4"}, b)
+}
+
 func TestSelectLimitAndOffset(t *testing.T) {
 	s := Select{}
 	s.Table("table")
@@ -2,13 +2,21 @@ package query
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
 type Update struct {
-	table  string
-	params map[string]interface{}
-	where  *condition
+	dialect   Dialect
+	table     string
+	params    map[string]interface{}
+	where     *condition
+	returning []string
+}
+
+func (u *Update) Dialect(d Dialect) *Update {
+	u.dialect = d
+	return u
 }
 
 func (u *Update) Table(table string) *Update {
@@ -21,29 +29,87 @@ func (u *Update) Params(params map[string]interface{}) *Update {
 	return u
 }
 
-func (u *Update) Where(cond string, args ...interface{}) *Update {
+func (u *Update) Where(cond interface{}, args ...interface{}) *Update {
+	return u.addWhere(AND, false, cond, args...)
+}
+
+func (u *Update) And(cond interface{}, args ...interface{}) *Update {
+	return u.Where(cond, args...)
+}
+
+func (u *Update) Or(cond interface{}, args ...interface{}) *Update {
+	return u.addWhere(OR, false, cond, args...)
+}
+
+func (u *Update) Not(cond interface{}, args ...interface{}) *Update {
+	return u.addWhere(AND, true, cond, args...)
+}
+
+func (u *Update) addWhere(conj string, not bool, cond interface{}, args ...interface{}) *Update {
 	if u.where == nil {
 		u.where = &condition{phrase: "WHERE"}
 	}
-	u.where.addExpression(cond, args...)
+	u.where.add(conj, not, cond, args...)
 	return u
 }
 
-func (u *Update) And(cond string, args ...interface{}) *Update {
-	return u.Where(cond, args...)
+func (u *Update) Merge(s *Select, pk string) *Update {
+	u.table = s.table
+	if err := s.ConditionError(); err != nil {
+		if u.where == nil {
+			u.where = &condition{phrase: "WHERE"}
+		}
+		u.where.setError(err)
+	}
+	if s.isSimple() {
+		return u.Where(s)
+	}
+	return u.Where(pk, "IN", s.primaryKeys(pk))
+}
+
+func (u *Update) Returning(columns ...string) *Update {
+	u.returning = columns
+	return u
+}
+
+func (u *Update) ConditionError() error {
+	return u.where.error()
+}
+
+func (u *Update) whereCondition() *condition {
+	return u.where
 }
 
 func (u *Update) Build() (string, []interface{}) {
+	d := dialectOf(u.dialect)
 	sets := []string{}
 	binds := []interface{}{}
 
-	for k, v := range u.params {
-		sets = append(sets, fmt.Sprintf("%s = ?", k))
+	keys := make([]string, 0, len(u.params))
+	for k := range u.params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := u.params[k]
+		if expr, ok := v.(Expression); ok {
+			q, b := expr.build(d)
+			sets = append(sets, fmt.Sprintf("%s = %s", quoteIdentifier(d, k), q))
+			binds = append(binds, b...)
+			continue
+		}
+		sets = append(sets, fmt.Sprintf("%s = ?", quoteIdentifier(d, k)))
 		binds = append(binds, v)
 	}
 
-	baseQuery := fmt.Sprintf("UPDATE %s SET %s", u.table, strings.Join(sets, ", "))
+	query := fmt.Sprintf("UPDATE %s SET %s", quoteIdentifier(d, u.table), strings.Join(sets, ", "))
 
-	whereQuery, whereBinds := u.where.build()
-	return baseQuery + whereQuery + ";", append(binds, whereBinds...)
+	if !u.where.isEmpty() {
+		q, b := u.where.build(d)
+		query += q
+		binds = append(binds, b...)
+	}
+	query += buildReturning(d, u.returning)
+	return rebind(d, query+";"), binds
 }
@@ -15,3 +15,78 @@ func TestUpdate(t *testing.T) {
 	assertQuery(t, "UPDATE table SET columnA = ? WHERE columnA = ?;", q)
 	assertBinds(t, []interface{}{"value1", "value1"}, b)
 }
+
+func TestUpdateDeterministic(t *testing.T) {
+	update := Update{}
+	update.Table("table")
+	update.Params(map[string]interface{}{
+		"columnC": "value3",
+		"columnA": "value1",
+		"columnB": "value2",
+	})
+
+	for i := 0; i < 10; i++ {
+		q, b := update.Build()
+
+		assertQuery(t, "UPDATE table SET columnA = ?, columnB = ?, columnC = ?;", q)
+		assertBinds(t, []interface{}{"value1", "value2", "value3"}, b)
+	}
+}
+
+func TestUpdateReturning(t *testing.T) {
+	update := Update{}
+	update.Table("table")
+	update.Params(map[string]interface{}{
+		"columnA": "value1",
+	})
+	update.Where("columnB", "value2")
+	update.Returning("id")
+
+	q, b := update.Build()
+
+	assertQuery(t, "UPDATE table SET columnA = ? WHERE columnB = ? RETURNING id;", q)
+	assertBinds(t, []interface{}{"value1", "value2"}, b)
+}
+
+func TestUpdateMerge(t *testing.T) {
+	s := &Select{}
+	s.Table("table").Columns("columnA").Where("columnA", "value1").Or("columnB", "value2")
+
+	update := Update{}
+	update.Params(map[string]interface{}{
+		"columnA": "value3",
+	})
+	update.Merge(s, "id")
+
+	q, b := update.Build()
+
+	assertQuery(t, "UPDATE table SET columnA = ? WHERE (columnA = ? OR columnB = ?);", q)
+	assertBinds(t, []interface{}{"value3", "value1", "value2"}, b)
+
+	s.InnerJoin("tableB", "tableB.table_id = table.id").Limit(10)
+
+	update = Update{}
+	update.Params(map[string]interface{}{
+		"columnA": "value3",
+	})
+	update.Merge(s, "id")
+
+	q, b = update.Build()
+
+	assertQuery(t, "UPDATE table SET columnA = ? WHERE id IN (SELECT id FROM (SELECT table.id FROM table INNER JOIN tableB ON tableB.table_id = table.id WHERE columnA = ? OR columnB = ? LIMIT ?) AS t);", q)
+	assertBinds(t, []interface{}{"value3", "value1", "value2", 10}, b)
+}
+
+func TestUpdateExpression(t *testing.T) {
+	update := Update{}
+	update.Table("table")
+	update.Params(map[string]interface{}{
+		"columnA": Expr("columnA + ?", 1),
+	})
+	update.Where("id", 1)
+
+	q, b := update.Build()
+
+	assertQuery(t, "UPDATE table SET columnA = columnA + ? WHERE id = ?;", q)
+	assertBinds(t, []interface{}{1, 1}, b)
+}
@@ -0,0 +1,53 @@
+package query
+
+import "errors"
+
+var (
+	ErrConflictTargetRequired = errors.New("conflict target is required to update on conflict")
+	ErrUpsertNotSupported     = errors.New("upsert is not supported by the dialect")
+)
+
+type upsert struct {
+	target   []string
+	updates  []string
+	doUpdate bool
+}
+
+func (u *upsert) setTarget(columns ...string) {
+	u.target = columns
+}
+
+func (u *upsert) setUpdates(doUpdate bool, columns ...string) {
+	u.doUpdate = doUpdate
+	u.updates = columns
+}
+
+func (u *upsert) build(d Dialect, columns []string) string {
+	query, _ := u.onConflict(d, columns)
+	return query
+}
+
+func (u *upsert) onConflict(d Dialect, columns []string) (string, error) {
+	var updates []string
+	if u.doUpdate {
+		updates = u.updates
+		if len(updates) == 0 {
+			updates = u.excluded(columns)
+		}
+	}
+	return d.OnConflict(quoteIdentifiers(d, columns), quoteIdentifiers(d, u.target), quoteIdentifiers(d, updates))
+}
+
+func (u *upsert) excluded(columns []string) []string {
+	target := map[string]bool{}
+	for _, c := range u.target {
+		target[c] = true
+	}
+	var updates []string
+	for _, c := range columns {
+		if !target[c] {
+			updates = append(updates, c)
+		}
+	}
+	return updates
+}
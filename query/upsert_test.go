@@ -0,0 +1,29 @@
+package query
+
+import "testing"
+
+func TestUpsertDoNothing(t *testing.T) {
+	u := upsert{}
+	u.setTarget("columnA", "columnB")
+
+	assertQuery(t, " ON CONFLICT (columnA, columnB) DO NOTHING", u.build(defaultDialect{}, []string{"columnA", "columnB", "columnC"}))
+	assertQuery(t, " ON DUPLICATE KEY UPDATE columnA = columnA", u.build(MySQL, []string{"columnA", "columnB", "columnC"}))
+
+	u.setTarget()
+
+	assertQuery(t, " ON CONFLICT DO NOTHING", u.build(defaultDialect{}, []string{"columnA", "columnB", "columnC"}))
+	assertQuery(t, " ON DUPLICATE KEY UPDATE columnC = columnC", u.build(MySQL, []string{"columnC", "columnB", "columnA"}))
+}
+
+func TestUpsertDoUpdate(t *testing.T) {
+	u := upsert{}
+	u.setTarget("columnA")
+	u.setUpdates(true)
+
+	assertQuery(t, " ON CONFLICT (columnA) DO UPDATE SET columnB = excluded.columnB, columnC = excluded.columnC", u.build(defaultDialect{}, []string{"columnA", "columnB", "columnC"}))
+	assertQuery(t, " ON DUPLICATE KEY UPDATE columnB = VALUES(columnB), columnC = VALUES(columnC)", u.build(MySQL, []string{"columnA", "columnB", "columnC"}))
+
+	u.setUpdates(true, "columnC", "order")
+
+	assertQuery(t, ` ON CONFLICT (columnA) DO UPDATE SET columnC = excluded.columnC, "order" = excluded."order"`, u.build(PostgreSQL, []string{"columnA", "columnB", "columnC"}))
+}
@@ -0,0 +1,37 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+type with struct {
+	ctes []*cte
+}
+
+type cte struct {
+	name      string
+	query     subquery
+	recursive bool
+}
+
+func (w *with) addCte(name string, query interface{}, recursive bool) {
+	if sub, ok := query.(subquery); ok {
+		w.ctes = append(w.ctes, &cte{name: name, query: sub, recursive: recursive})
+	}
+}
+
+func (w *with) build(d Dialect) (string, []interface{}) {
+	var binds []interface{}
+	phrase := "WITH"
+	ctes := make([]string, len(w.ctes))
+	for i, c := range w.ctes {
+		if c.recursive {
+			phrase = "WITH RECURSIVE"
+		}
+		q, b := c.query.buildSubquery(d)
+		ctes[i] = fmt.Sprintf("%s AS (%s)", c.name, q)
+		binds = append(binds, b...)
+	}
+	return fmt.Sprintf("%s %s ", phrase, strings.Join(ctes, ", ")), binds
+}
@@ -0,0 +1,33 @@
+package query
+
+import "testing"
+
+func TestWith(t *testing.T) {
+	s := &Select{}
+	s.Table("table").Columns("columnA").Where("columnB", "value")
+
+	w := with{}
+	w.addCte("cteA", s, false)
+	w.addCte("cteB", s, false)
+
+	q, b := w.build(defaultDialect{})
+
+	assertQuery(t, "WITH cteA AS (SELECT columnA FROM table WHERE columnB = ?), cteB AS (SELECT columnA FROM table WHERE columnB = ?) ", q)
+	assertBinds(t, []interface{}{"value", "value"}, b)
+}
+
+func TestWithRecursive(t *testing.T) {
+	recursive := &Select{}
+	recursive.Table("table").Columns("table.id").InnerJoin("tree", "table.parent_id = tree.id")
+
+	s := &Select{}
+	s.Table("table").Columns("id").Where("id", 1).UnionAll(recursive)
+
+	w := with{}
+	w.addCte("tree(id)", s, true)
+
+	q, b := w.build(defaultDialect{})
+
+	assertQuery(t, "WITH RECURSIVE tree(id) AS (SELECT id FROM table WHERE id = ? UNION ALL SELECT table.id FROM table INNER JOIN tree ON table.parent_id = tree.id) ", q)
+	assertBinds(t, []interface{}{1}, b)
+}
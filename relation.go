@@ -1,11 +1,11 @@
 package ar
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
 	"strings"
-	"time"
 	"unicode"
 
 	"github.com/monochromegane/argen/query"
@@ -14,23 +14,76 @@ import (
 
 type Relation struct {
 	*query.Select
-	db     *sql.DB
-	logger *Logger
+	exec       *Executer
+	primaryKey string
+	strictLock bool
 }
 
 func NewRelation(db *sql.DB, logger *Logger) *Relation {
+	return newRelation(db, logger)
+}
+
+func NewRelationTx(tx *Tx, logger *Logger) *Relation {
+	return newRelation(tx, logger)
+}
+
+func newRelation(conn Conn, logger *Logger) *Relation {
 	return &Relation{
 		Select: &query.Select{},
-		db:     db,
-		logger: logger,
+		exec:   &Executer{conn: conn, logger: logger},
+	}
+}
+
+func (r *Relation) Clone() *Relation {
+	exec := *r.exec
+	return &Relation{
+		Select:     r.Select.Clone(),
+		exec:       &exec,
+		primaryKey: r.primaryKey,
+		strictLock: r.strictLock,
 	}
 }
 
+func (r *Relation) Dialect(d query.Dialect) *Relation {
+	r.Select.Dialect(d)
+	return r
+}
+
+func (r *Relation) With(name string, query interface{}) *Relation {
+	r.Select.With(name, query)
+	return r
+}
+
+func (r *Relation) WithRecursive(name string, query interface{}) *Relation {
+	r.Select.WithRecursive(name, query)
+	return r
+}
+
+func (r *Relation) PrimaryKey(pk string) *Relation {
+	r.primaryKey = pk
+	return r
+}
+
+func (r *Relation) Cache(c *StmtCache) *Relation {
+	r.exec.cache = c
+	return r
+}
+
+func (r *Relation) StrictLock(strict bool) *Relation {
+	r.strictLock = strict
+	return r
+}
+
 func (r *Relation) Table(table string) *Relation {
 	r.Select.Table(table)
 	return r
 }
 
+func (r *Relation) From(source interface{}, alias string) *Relation {
+	r.Select.From(source, alias)
+	return r
+}
+
 func (r *Relation) Columns(columns ...string) *Relation {
 	r.Select.Columns(columns...)
 	return r
@@ -40,20 +93,123 @@ func (r *Relation) GetColumns() []string {
 	return r.Select.GetColumns()
 }
 
-func (r *Relation) Where(cond string, args ...interface{}) *Relation {
+func (r *Relation) Where(cond interface{}, args ...interface{}) *Relation {
 	r.Select.Where(cond, args...)
 	return r
 }
 
-func (r *Relation) And(cond string, args ...interface{}) *Relation {
+func (r *Relation) And(cond interface{}, args ...interface{}) *Relation {
 	return r.Where(cond, args...)
 }
 
+func (r *Relation) Or(cond interface{}, args ...interface{}) *Relation {
+	r.Select.Or(cond, args...)
+	return r
+}
+
+func (r *Relation) Not(cond interface{}, args ...interface{}) *Relation {
+	r.Select.Not(cond, args...)
+	return r
+}
+
+func (r *Relation) UnscopeWhere(columns ...string) *Relation {
+	r.Select.UnscopeWhere(columns...)
+	return r
+}
+
+func (r *Relation) Rewhere(cond string, args ...interface{}) *Relation {
+	r.Select.Rewhere(cond, args...)
+	return r
+}
+
+func (r *Relation) Unscope(clauses ...query.Clause) *Relation {
+	r.Select.Unscope(clauses...)
+	return r
+}
+
+func (r *Relation) Unscoped() *Relation {
+	return r.Unscope(query.Clauses...)
+}
+
+func (r *Relation) WhereExists(sub interface{}) *Relation {
+	r.Select.WhereExists(sub)
+	return r
+}
+
+func (r *Relation) WhereNotExists(sub interface{}) *Relation {
+	r.Select.WhereNotExists(sub)
+	return r
+}
+
+func (r *Relation) InnerJoin(table string, cond string, args ...interface{}) *Relation {
+	r.Select.InnerJoin(table, cond, args...)
+	return r
+}
+
+func (r *Relation) LeftJoin(table string, cond string, args ...interface{}) *Relation {
+	r.Select.LeftJoin(table, cond, args...)
+	return r
+}
+
+func (r *Relation) RightJoin(table string, cond string, args ...interface{}) *Relation {
+	r.Select.RightJoin(table, cond, args...)
+	return r
+}
+
+func (r *Relation) FullJoin(table string, cond string, args ...interface{}) *Relation {
+	r.Select.FullJoin(table, cond, args...)
+	return r
+}
+
+func (r *Relation) CrossJoin(table string) *Relation {
+	r.Select.CrossJoin(table)
+	return r
+}
+
+func (r *Relation) Joins(sql string, args ...interface{}) *Relation {
+	r.Select.Joins(sql, args...)
+	return r
+}
+
+func (r *Relation) Union(query interface{}) *Relation {
+	r.Select.Union(query)
+	return r
+}
+
+func (r *Relation) UnionAll(query interface{}) *Relation {
+	r.Select.UnionAll(query)
+	return r
+}
+
+func (r *Relation) Intersect(query interface{}) *Relation {
+	r.Select.Intersect(query)
+	return r
+}
+
+func (r *Relation) Except(query interface{}) *Relation {
+	r.Select.Except(query)
+	return r
+}
+
 func (r *Relation) OrderBy(column, order string) *Relation {
 	r.Select.OrderBy(column, order)
 	return r
 }
 
+func (r *Relation) Reorder(column, order string) *Relation {
+	r.Select.Reorder(column, order)
+	return r
+}
+
+func (r *Relation) ReverseOrder() *Relation {
+	if !r.HasClause(query.OrderClause) {
+		r.Select.OrderBy(r.getPrimaryKey(), query.DESC)
+		return r
+	}
+	r.Select.ReverseOrder()
+	return r
+}
+
 func (r *Relation) Limit(limit int) *Relation {
 	r.Select.Limit(limit)
 	return r
@@ -74,33 +230,97 @@ func (r *Relation) Having(cond string, args ...interface{}) *Relation {
 	return r
 }
 
+func (r *Relation) Lock(mode query.LockMode) *Relation {
+	r.Select.Lock(mode)
+	return r
+}
+
 func (r *Relation) Build() (string, []interface{}) {
 	return r.Select.Build()
 }
 
 func (r *Relation) Count(column ...string) int {
+	return r.CountContext(context.Background(), column...)
+}
+
+func (r *Relation) CountContext(ctx context.Context, column ...string) int {
 	c := "*"
 	if len(column) > 0 {
 		c = column[0]
 	}
 	var count int
-	if err := r.Columns(fmt.Sprintf("COUNT(%s)", c)).QueryRow(&count); err != nil {
+	if err := r.aggregate(fmt.Sprintf("COUNT(%s)", c)).QueryRowContext(ctx, &count); err != nil {
 		return 0
 	}
 	return count
 }
 
 func (r *Relation) Exists() bool {
+	return r.ExistsContext(context.Background())
+}
+
+func (r *Relation) ExistsContext(ctx context.Context) bool {
 	var one int
-	if err := r.Columns("1").Limit(1).QueryRow(&one); err == sql.ErrNoRows {
+	if err := r.aggregate("1").Limit(1).QueryRowContext(ctx, &one); err != nil {
 		return false
 	}
 	return true
 }
 
+func (r *Relation) aggregate(column string) *Relation {
+	c := r.Clone()
+	c.Unscope(query.LockClause)
+	if c.IsCompound() {
+		c.Select = (&query.Select{}).Dialect(r.GetDialect()).From(c.Clone().Select, "t")
+	}
+	return c.Columns(column)
+}
+
+func (r *Relation) UpdateAll(params map[string]interface{}) (int64, error) {
+	return r.UpdateAllContext(context.Background(), params)
+}
+
+func (r *Relation) UpdateAllContext(ctx context.Context, params map[string]interface{}) (int64, error) {
+	if err := r.ConditionError(); err != nil {
+		return 0, err
+	}
+	u := &Update{Update: &query.Update{}, exec: r.exec}
+	u.Update.Dialect(r.GetDialect()).Params(params).Merge(r.Select, r.getPrimaryKey())
+	result, err := u.ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *Relation) DeleteAll() (int64, error) {
+	return r.DeleteAllContext(context.Background())
+}
+
+func (r *Relation) DeleteAllContext(ctx context.Context) (int64, error) {
+	if err := r.ConditionError(); err != nil {
+		return 0, err
+	}
+	d := &Delete{Delete: &query.Delete{}, exec: r.exec}
+	d.Delete.Dialect(r.GetDialect()).Merge(r.Select, r.getPrimaryKey())
+	result, err := d.ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *Relation) getPrimaryKey() string {
+	if r.primaryKey == "" {
+		return "id"
+	}
+	return r.primaryKey
+}
+
 func (r *Relation) Explain() error {
-	r.Select.Explain()
-	rows, err := r.Query()
+	c := r.Clone()
+	c.Select.Explain()
+	rows, err := c.Query()
 	if err != nil {
 		return err
 	}
@@ -123,19 +343,40 @@ func (r *Relation) Explain() error {
 }
 
 func (r *Relation) Query() (*sql.Rows, error) {
+	return r.QueryContext(context.Background())
+}
+
+func (r *Relation) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	if err := r.ConditionError(); err != nil {
+		return nil, err
+	}
+	if err := r.lockError(); err != nil {
+		return nil, err
+	}
 	q, b := r.Build()
-	defer r.log(time.Now(), q, b...)
-	return r.db.Query(q, b...)
+	return r.exec.QueryContext(ctx, q, b...)
 }
 
 func (r *Relation) QueryRow(dest ...interface{}) error {
+	return r.QueryRowContext(context.Background(), dest...)
+}
+
+func (r *Relation) QueryRowContext(ctx context.Context, dest ...interface{}) error {
+	if err := r.ConditionError(); err != nil {
+		return err
+	}
+	if err := r.lockError(); err != nil {
+		return err
+	}
 	q, b := r.Build()
-	defer r.log(time.Now(), q, b...)
-	return r.db.QueryRow(q, b...).Scan(dest...)
+	return r.exec.QueryRowContext(ctx, q, b...).Scan(dest...)
 }
 
-func (r *Relation) log(t time.Time, sql string, args ...interface{}) {
-	r.logger.Print(time.Now().Sub(t), sql, args)
+func (r *Relation) lockError() error {
+	if !r.strictLock {
+		return nil
+	}
+	return r.LockError()
 }
 
 func IsZero(v interface{}) bool {
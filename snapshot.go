@@ -0,0 +1,40 @@
+package ar
+
+import "reflect"
+
+type Snapshot struct {
+	values map[string]interface{}
+}
+
+type Change struct {
+	Old interface{}
+	New interface{}
+}
+
+func (s *Snapshot) TakeSnapshot(values map[string]interface{}) {
+	// copies of a model share the map, so replace it instead of writing to it
+	merged := make(map[string]interface{}, len(s.values)+len(values))
+	for k, v := range s.values {
+		merged[k] = v
+	}
+	for k, v := range values {
+		merged[k] = v
+	}
+	s.values = merged
+}
+
+func (s *Snapshot) SnapshotChange(column string, value interface{}) (Change, bool) {
+	old, ok := s.values[column]
+	if !ok {
+		return Change{New: value}, true
+	}
+	return Change{Old: old, New: value}, !reflect.DeepEqual(old, value)
+}
+
+func (s *Snapshot) RestoreSnapshot(column string, ptr interface{}) {
+	old, ok := s.values[column]
+	if !ok || ptr == nil {
+		return
+	}
+	reflect.ValueOf(ptr).Elem().Set(reflect.ValueOf(old))
+}
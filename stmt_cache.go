@@ -0,0 +1,126 @@
+package ar
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+)
+
+type StmtCache struct {
+	db    *sql.DB
+	size  int
+	mu    sync.Mutex
+	items map[string]*list.Element
+	lru   *list.List
+}
+
+type cachedStmt struct {
+	query   string
+	stmt    *sql.Stmt
+	refs    int
+	evicted bool
+}
+
+func NewStmtCache(db *sql.DB, size int) *StmtCache {
+	return &StmtCache{
+		db:    db,
+		size:  size,
+		items: map[string]*list.Element{},
+		lru:   list.New(),
+	}
+}
+
+func (c *StmtCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+func (c *StmtCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var err error
+	for c.lru.Len() > 0 {
+		if e := c.evict(c.lru.Back()); e != nil {
+			err = e
+		}
+	}
+	return err
+}
+
+func (c *StmtCache) stmt(ctx context.Context, conn Conn, query string) (*sql.Stmt, func(), error) {
+	if tx, ok := conn.(*Tx); ok {
+		// Preparing on the DB would take another connection, so reuse cached statements only.
+		cs := c.get(query)
+		if cs == nil {
+			return nil, nil, nil
+		}
+		return tx.tx.StmtContext(ctx, cs.stmt), func() { c.release(cs) }, nil
+	}
+	cs, err := c.acquire(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cs.stmt, func() { c.release(cs) }, nil
+}
+
+func (c *StmtCache) acquire(ctx context.Context, query string) (*cachedStmt, error) {
+	if cs := c.get(query); cs != nil {
+		return cs, nil
+	}
+
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[query]; ok {
+		stmt.Close()
+		c.lru.MoveToFront(el)
+		cs := el.Value.(*cachedStmt)
+		cs.refs++
+		return cs, nil
+	}
+	cs := &cachedStmt{query: query, stmt: stmt, refs: 1}
+	c.items[query] = c.lru.PushFront(cs)
+	for c.lru.Len() > c.size {
+		c.evict(c.lru.Back())
+	}
+	return cs, nil
+}
+
+func (c *StmtCache) get(query string) *cachedStmt {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[query]
+	if !ok {
+		return nil
+	}
+	c.lru.MoveToFront(el)
+	cs := el.Value.(*cachedStmt)
+	cs.refs++
+	return cs
+}
+
+func (c *StmtCache) release(cs *cachedStmt) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cs.refs--
+	if cs.evicted && cs.refs == 0 {
+		cs.stmt.Close()
+	}
+}
+
+func (c *StmtCache) evict(el *list.Element) error {
+	cs := el.Value.(*cachedStmt)
+	c.lru.Remove(el)
+	delete(c.items, cs.query)
+	cs.evicted = true
+	if cs.refs == 0 {
+		return cs.stmt.Close()
+	}
+	return nil
+}
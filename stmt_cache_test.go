@@ -0,0 +1,94 @@
+package ar
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openStmtCacheDb(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestStmtCacheConcurrency(t *testing.T) {
+	db := openStmtCacheDb(t)
+	defer db.Close()
+
+	c := NewStmtCache(db, 2)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				n := (g + i) % 5
+				stmt, release, err := c.stmt(ctx, db, fmt.Sprintf("SELECT %d;", n))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				var v int
+				if err := stmt.QueryRowContext(ctx).Scan(&v); err != nil || v != n {
+					t.Errorf("statement should return %v, but %v (%v)", n, v, err)
+				}
+				release()
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if c.Len() > 2 {
+		t.Errorf("cached statement count should be at most 2, but %v", c.Len())
+	}
+	if err := c.Close(); err != nil {
+		t.Error(err)
+	}
+	if c.Len() != 0 {
+		t.Errorf("cached statement count should be 0, but %v", c.Len())
+	}
+}
+
+func TestStmtCacheEvictReferenced(t *testing.T) {
+	db := openStmtCacheDb(t)
+	defer db.Close()
+
+	c := NewStmtCache(db, 1)
+	ctx := context.Background()
+
+	cs1, err := c.acquire(ctx, "SELECT 1;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs2, err := c.acquire(ctx, "SELECT 2;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.release(cs2)
+
+	if !cs1.evicted {
+		t.Fatalf("statement should be evicted")
+	}
+	var v int
+	if err := cs1.stmt.QueryRowContext(ctx).Scan(&v); err != nil || v != 1 {
+		t.Errorf("evicted statement should be usable while referenced, but %v (%v)", v, err)
+	}
+
+	c.release(cs1)
+
+	if err := cs1.stmt.QueryRowContext(ctx).Scan(&v); err == nil {
+		t.Errorf("evicted statement should be closed after release")
+	}
+	if c.Len() != 1 {
+		t.Errorf("cached statement count should be 1, but %v", c.Len())
+	}
+}
@@ -0,0 +1,86 @@
+//go:generate go run ../cmd/argen/main.go
+package tests
+
+import (
+	"fmt"
+	"time"
+)
+
+//+AR
+type Comment struct {
+	Id        int `db:"pk"`
+	PostId    int `db:"fk"`
+	ParentId  int
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+var commentCallbacks []string
+
+func (c *Comment) beforeValidation() error {
+	commentCallbacks = append(commentCallbacks, "beforeValidation")
+	return nil
+}
+
+func (c *Comment) afterValidation() error {
+	commentCallbacks = append(commentCallbacks, "afterValidation")
+	return nil
+}
+
+func (c *Comment) beforeSave() error {
+	commentCallbacks = append(commentCallbacks, "beforeSave")
+	if c.Body == "" {
+		c.Body = "(empty)"
+	}
+	return nil
+}
+
+func (c *Comment) afterSave() error {
+	commentCallbacks = append(commentCallbacks, "afterSave")
+	return nil
+}
+
+func (c *Comment) beforeCreate() error {
+	commentCallbacks = append(commentCallbacks, "beforeCreate")
+	if c.Body == "abort" {
+		return fmt.Errorf("can't create")
+	}
+	return nil
+}
+
+func (c *Comment) afterCreate() error {
+	commentCallbacks = append(commentCallbacks, "afterCreate")
+	return nil
+}
+
+func (c *Comment) beforeUpdate() error {
+	commentCallbacks = append(commentCallbacks, "beforeUpdate")
+	return nil
+}
+
+func (c *Comment) afterUpdate() error {
+	commentCallbacks = append(commentCallbacks, "afterUpdate")
+	return nil
+}
+
+func (c *Comment) beforeDestroy() error {
+	commentCallbacks = append(commentCallbacks, "beforeDestroy")
+	if c.Body == "locked" {
+		return fmt.Errorf("can't destroy")
+	}
+	return nil
+}
+
+func (c *Comment) afterDestroy() error {
+	commentCallbacks = append(commentCallbacks, "afterDestroy")
+	return nil
+}
+
+func (c *Comment) afterCommit() {
+	commentCallbacks = append(commentCallbacks, "afterCommit")
+}
+
+func (c *Comment) afterRollback() {
+	commentCallbacks = append(commentCallbacks, "afterRollback")
+}
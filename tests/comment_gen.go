@@ -0,0 +1,1110 @@
+// generated by argen; DO NOT EDIT
+package tests
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/monochromegane/argen"
+	"github.com/monochromegane/argen/query"
+)
+
+type CommentRelation struct {
+	src *Comment
+	*ar.Relation
+}
+
+func (m *Comment) newRelation() *CommentRelation {
+	return m.newRelationTx(nil)
+}
+
+func (m *Comment) newRelationTx(tx *ar.Tx) *CommentRelation {
+	r := m.unscopedRelationTx(tx)
+
+	return r
+}
+
+func (m *Comment) unscopedRelationTx(tx *ar.Tx) *CommentRelation {
+	r := &CommentRelation{
+		m,
+		newRelation(tx).Table("comments").PrimaryKey("id"),
+	}
+	r.Select(
+		"id",
+		"post_id",
+		"parent_id",
+		"body",
+		"created_at",
+		"updated_at",
+	)
+	return r
+}
+
+func (r *CommentRelation) Clone() *CommentRelation {
+	return &CommentRelation{r.src, r.Relation.Clone()}
+}
+
+func (m Comment) Tx(tx *ar.Tx) *CommentRelation {
+	return m.newRelationTx(tx)
+}
+
+func (m Comment) Select(columns ...string) *CommentRelation {
+	return m.newRelation().Select(columns...)
+}
+
+func (r *CommentRelation) Select(columns ...string) *CommentRelation {
+	cs := []string{}
+	for _, c := range columns {
+		if r.src.isColumnName(c) {
+			cs = append(cs, fmt.Sprintf("comments.%s", c))
+		} else {
+			cs = append(cs, c)
+		}
+	}
+	r.Relation.Columns(cs...)
+	return r
+}
+
+func (m Comment) With(name string, query interface{}) *CommentRelation {
+	return m.newRelation().With(name, query)
+}
+
+func (r *CommentRelation) With(name string, query interface{}) *CommentRelation {
+	r.Relation.With(name, query)
+	return r
+}
+
+func (m Comment) WithRecursive(name string, query interface{}) *CommentRelation {
+	return m.newRelation().WithRecursive(name, query)
+}
+
+func (r *CommentRelation) WithRecursive(name string, query interface{}) *CommentRelation {
+	r.Relation.WithRecursive(name, query)
+	return r
+}
+
+func (m Comment) Find(id int) (*Comment, error) {
+	return m.newRelation().Find(id)
+}
+
+func (m Comment) FindContext(ctx context.Context, id int) (*Comment, error) {
+	return m.newRelation().FindContext(ctx, id)
+}
+
+func (r *CommentRelation) Find(id int) (*Comment, error) {
+	return r.FindContext(context.Background(), id)
+}
+
+func (r *CommentRelation) FindContext(ctx context.Context, id int) (*Comment, error) {
+	return r.FindByContext(ctx, "id", id)
+}
+
+func (m Comment) FindBy(cond string, args ...interface{}) (*Comment, error) {
+	return m.newRelation().FindBy(cond, args...)
+}
+
+func (m Comment) FindByContext(ctx context.Context, cond string, args ...interface{}) (*Comment, error) {
+	return m.newRelation().FindByContext(ctx, cond, args...)
+}
+
+func (r *CommentRelation) FindBy(cond string, args ...interface{}) (*Comment, error) {
+	return r.FindByContext(context.Background(), cond, args...)
+}
+
+func (r *CommentRelation) FindByContext(ctx context.Context, cond string, args ...interface{}) (*Comment, error) {
+	return r.Clone().Where(cond, args...).Limit(1).QueryRowContext(ctx)
+}
+
+func (m Comment) First() (*Comment, error) {
+	return m.newRelation().First()
+}
+
+func (m Comment) FirstContext(ctx context.Context) (*Comment, error) {
+	return m.newRelation().FirstContext(ctx)
+}
+
+func (r *CommentRelation) First() (*Comment, error) {
+	return r.FirstContext(context.Background())
+}
+
+func (r *CommentRelation) FirstContext(ctx context.Context) (*Comment, error) {
+	return r.Clone().Order("id", "ASC").Limit(1).QueryRowContext(ctx)
+}
+
+func (m Comment) Last() (*Comment, error) {
+	return m.newRelation().Last()
+}
+
+func (m Comment) LastContext(ctx context.Context) (*Comment, error) {
+	return m.newRelation().LastContext(ctx)
+}
+
+func (r *CommentRelation) Last() (*Comment, error) {
+	return r.LastContext(context.Background())
+}
+
+func (r *CommentRelation) LastContext(ctx context.Context) (*Comment, error) {
+	return r.Clone().Order("id", "DESC").Limit(1).QueryRowContext(ctx)
+}
+
+func (m Comment) Where(cond interface{}, args ...interface{}) *CommentRelation {
+	return m.newRelation().Where(cond, args...)
+}
+
+func (r *CommentRelation) Where(cond interface{}, args ...interface{}) *CommentRelation {
+	r.Relation.Where(cond, args...)
+	return r
+}
+
+func (r *CommentRelation) And(cond interface{}, args ...interface{}) *CommentRelation {
+	r.Relation.And(cond, args...)
+	return r
+}
+
+func (r *CommentRelation) Or(cond interface{}, args ...interface{}) *CommentRelation {
+	r.Relation.Or(cond, args...)
+	return r
+}
+
+func (m Comment) Not(cond interface{}, args ...interface{}) *CommentRelation {
+	return m.newRelation().Not(cond, args...)
+}
+
+func (r *CommentRelation) Not(cond interface{}, args ...interface{}) *CommentRelation {
+	r.Relation.Not(cond, args...)
+	return r
+}
+
+func (m Comment) WhereExists(sub interface{}) *CommentRelation {
+	return m.newRelation().WhereExists(sub)
+}
+
+func (r *CommentRelation) WhereExists(sub interface{}) *CommentRelation {
+	r.Relation.WhereExists(sub)
+	return r
+}
+
+func (m Comment) WhereNotExists(sub interface{}) *CommentRelation {
+	return m.newRelation().WhereNotExists(sub)
+}
+
+func (r *CommentRelation) WhereNotExists(sub interface{}) *CommentRelation {
+	r.Relation.WhereNotExists(sub)
+	return r
+}
+
+func (m Comment) From(source interface{}, alias string) *CommentRelation {
+	return m.newRelation().From(source, alias)
+}
+
+func (r *CommentRelation) From(source interface{}, alias string) *CommentRelation {
+	r.Relation.From(source, alias)
+	return r
+}
+
+func (m Comment) Order(column, order string) *CommentRelation {
+	return m.newRelation().Order(column, order)
+}
+
+func (r *CommentRelation) Order(column, order string) *CommentRelation {
+	r.Relation.OrderBy(column, order)
+	return r
+}
+
+func (m Comment) Unscoped() *CommentRelation {
+	return m.unscopedRelationTx(nil)
+}
+
+func (r *CommentRelation) Unscoped() *CommentRelation {
+	r.Relation.Unscoped()
+	return r
+}
+
+func (m Comment) Unscope(clauses ...query.Clause) *CommentRelation {
+	return m.newRelation().Unscope(clauses...)
+}
+
+func (r *CommentRelation) Unscope(clauses ...query.Clause) *CommentRelation {
+	r.Relation.Unscope(clauses...)
+	return r
+}
+
+func (m Comment) Rewhere(cond string, args ...interface{}) *CommentRelation {
+	return m.newRelation().Rewhere(cond, args...)
+}
+
+func (r *CommentRelation) Rewhere(cond string, args ...interface{}) *CommentRelation {
+	r.Relation.Rewhere(cond, args...)
+	return r
+}
+
+func (m Comment) Reorder(column, order string) *CommentRelation {
+	return m.newRelation().Reorder(column, order)
+}
+
+func (r *CommentRelation) Reorder(column, order string) *CommentRelation {
+	r.Relation.Reorder(column, order)
+	return r
+}
+
+func (m Comment) ReverseOrder() *CommentRelation {
+	return m.newRelation().ReverseOrder()
+}
+
+func (r *CommentRelation) ReverseOrder() *CommentRelation {
+	r.Relation.ReverseOrder()
+	return r
+}
+
+func (m Comment) Limit(limit int) *CommentRelation {
+	return m.newRelation().Limit(limit)
+}
+
+func (r *CommentRelation) Limit(limit int) *CommentRelation {
+	r.Relation.Limit(limit)
+	return r
+}
+
+func (m Comment) Offset(offset int) *CommentRelation {
+	return m.newRelation().Offset(offset)
+}
+
+func (r *CommentRelation) Offset(offset int) *CommentRelation {
+	r.Relation.Offset(offset)
+	return r
+}
+
+func (m Comment) Group(group string, groups ...string) *CommentRelation {
+	return m.newRelation().Group(group, groups...)
+}
+
+func (r *CommentRelation) Group(group string, groups ...string) *CommentRelation {
+	r.Relation.GroupBy(group, groups...)
+	return r
+}
+
+func (r *CommentRelation) Having(cond string, args ...interface{}) *CommentRelation {
+	r.Relation.Having(cond, args...)
+	return r
+}
+
+func (m Comment) Joins(sql string, args ...interface{}) *CommentRelation {
+	return m.newRelation().Joins(sql, args...)
+}
+
+func (r *CommentRelation) Joins(sql string, args ...interface{}) *CommentRelation {
+	r.Relation.Joins(sql, args...)
+	return r
+}
+
+func (r *CommentRelation) Union(query interface{}) *CommentRelation {
+	r.Relation.Union(query)
+	return r
+}
+
+func (r *CommentRelation) UnionAll(query interface{}) *CommentRelation {
+	r.Relation.UnionAll(query)
+	return r
+}
+
+func (r *CommentRelation) Intersect(query interface{}) *CommentRelation {
+	r.Relation.Intersect(query)
+	return r
+}
+
+func (r *CommentRelation) Except(query interface{}) *CommentRelation {
+	r.Relation.Except(query)
+	return r
+}
+
+func (m Comment) Lock(mode query.LockMode) *CommentRelation {
+	return m.newRelation().Lock(mode)
+}
+
+func (r *CommentRelation) Lock(mode query.LockMode) *CommentRelation {
+	r.Relation.Lock(mode)
+	return r
+}
+
+func (m *Comment) Reload(lock ...query.LockMode) error {
+	return m.ReloadContext(context.Background(), lock...)
+}
+
+func (m *Comment) ReloadTx(tx *ar.Tx, lock ...query.LockMode) error {
+	return m.reload(tx.Context(), tx, lock)
+}
+
+func (m *Comment) ReloadContext(ctx context.Context, lock ...query.LockMode) error {
+	return m.reload(ctx, nil, lock)
+}
+
+func (m *Comment) reload(ctx context.Context, tx *ar.Tx, lock []query.LockMode) error {
+	r := m.newRelationTx(tx).Where("id", m.Id)
+	if len(lock) > 0 {
+		r.Lock(lock[0])
+	}
+	if err := r.Relation.QueryRowContext(ctx, m.fieldPtrsByName(r.Relation.GetColumns())...); err != nil {
+		return err
+	}
+	m.takeSnapshot()
+	return nil
+}
+
+func (m *Comment) IsValid() (bool, *ar.Errors) {
+	result := true
+	errors := &ar.Errors{}
+
+	if err := m.beforeValidation(); err != nil {
+		errors.AddError("base", err)
+		return false, errors
+	}
+	var on ar.On
+	if m.IsNewRecord() {
+		on = ar.OnCreate()
+	} else {
+		on = ar.OnUpdate()
+	}
+	rules := map[string]*ar.Validation{}
+	for name, rule := range rules {
+		if ok, errs := ar.NewValidator(rule).On(on).IsValid(m.fieldValueByName(name)); !ok {
+			result = false
+			errors.SetErrors(name, errs)
+		}
+	}
+	customs := []*ar.Validation{}
+	for _, rule := range customs {
+		custom := ar.NewValidator(rule).On(on).Custom()
+		custom(errors)
+	}
+	if len(errors.Messages) > 0 {
+		result = false
+	}
+
+	if err := m.afterValidation(); err != nil {
+		errors.AddError("base", err)
+		result = false
+	}
+	return result, errors
+}
+
+func (m *Comment) takeSnapshot(columns ...string) {
+}
+
+func (m *Comment) changedColumns() []string {
+	columns := []string{}
+	for _, c := range m.columnNames() {
+		if c == "id" {
+			continue
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+func (m *Comment) setCreateTimestamps() {
+	now := ar.Now()
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = now
+	}
+	if m.UpdatedAt.IsZero() {
+		m.UpdatedAt = now
+	}
+}
+
+func (m *Comment) setUpdateTimestamps() {
+	m.UpdatedAt = ar.Now()
+}
+
+type CommentParams Comment
+
+func (m Comment) Build(p CommentParams) *Comment {
+	return &Comment{
+		Id:        p.Id,
+		PostId:    p.PostId,
+		ParentId:  p.ParentId,
+		Body:      p.Body,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+func (m Comment) Create(p CommentParams) (*Comment, *ar.Errors) {
+	return m.create(context.Background(), nil, p)
+}
+
+func (m Comment) CreateTx(tx *ar.Tx, p CommentParams) (*Comment, *ar.Errors) {
+	return m.create(tx.Context(), tx, p)
+}
+
+func (m Comment) CreateContext(ctx context.Context, p CommentParams) (*Comment, *ar.Errors) {
+	return m.create(ctx, nil, p)
+}
+
+func (m Comment) create(ctx context.Context, tx *ar.Tx, p CommentParams) (*Comment, *ar.Errors) {
+	n := m.Build(p)
+	_, errs := n.save(ctx, tx)
+	return n, errs
+}
+
+func (m Comment) Upsert(p CommentParams, conflictColumns ...string) (*Comment, *ar.Errors) {
+	return m.upsert(context.Background(), nil, p, conflictColumns...)
+}
+
+func (m Comment) UpsertTx(tx *ar.Tx, p CommentParams, conflictColumns ...string) (*Comment, *ar.Errors) {
+	return m.upsert(tx.Context(), tx, p, conflictColumns...)
+}
+
+func (m Comment) UpsertContext(ctx context.Context, p CommentParams, conflictColumns ...string) (*Comment, *ar.Errors) {
+	return m.upsert(ctx, nil, p, conflictColumns...)
+}
+
+func (m Comment) upsert(ctx context.Context, tx *ar.Tx, p CommentParams, conflictColumns ...string) (*Comment, *ar.Errors) {
+	n := m.Build(p)
+	if ok, errs := n.IsValid(); !ok {
+		return nil, errs
+	}
+	errs := &ar.Errors{}
+
+	n.setCreateTimestamps()
+	params := map[string]interface{}{
+		"post_id":    n.PostId,
+		"parent_id":  n.ParentId,
+		"body":       n.Body,
+		"created_at": n.CreatedAt,
+		"updated_at": n.UpdatedAt,
+	}
+	rel := newRelation(tx).Table("comments").Columns(n.columnNames()...)
+	for _, c := range conflictColumns {
+		if !n.isColumnName(c) {
+			errs.Add(c, "is not a column")
+			return nil, errs
+		}
+		v := n.fieldValueByName(c)
+		if _, ok := params[c]; !ok {
+			if ar.IsZero(v) {
+				errs.Add(c, "can't be blank")
+				return nil, errs
+			}
+			params[c] = v
+		}
+		rel.Where(c, v)
+	}
+	ins := newInsert(tx).Table("comments").Params(params).OnConflict(conflictColumns...)
+	if columns := m.upsertColumns(conflictColumns); len(columns) > 0 {
+		ins.DoUpdate(columns...)
+	} else if len(conflictColumns) > 0 {
+		ins.DoUpdate(conflictColumns...)
+	} else {
+		ins.DoNothing()
+	}
+	if err := ins.ExecReturningContext(ctx, "id", &n.Id); err != nil {
+		errs.AddError("base", err)
+		return nil, errs
+	}
+	if len(conflictColumns) == 0 {
+		rel.Where("id", n.Id)
+	}
+	if err := rel.QueryRowContext(ctx, n.fieldPtrsByName(n.columnNames())...); err != nil {
+		errs.AddError("base", err)
+		return nil, errs
+	}
+	n.takeSnapshot()
+	return n, nil
+}
+
+func (m Comment) upsertColumns(conflictColumns []string) []string {
+	target := map[string]bool{}
+	for _, c := range conflictColumns {
+		target[c] = true
+	}
+	columns := []string{}
+	for _, c := range m.columnNames() {
+		if c == "id" || c == "created_at" || target[c] {
+			continue
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+func (m Comment) UpsertAll(ps []CommentParams, conflictColumns ...string) ([]*Comment, *ar.Errors) {
+	return m.upsertAll(context.Background(), nil, ps, conflictColumns...)
+}
+
+func (m Comment) UpsertAllTx(tx *ar.Tx, ps []CommentParams, conflictColumns ...string) ([]*Comment, *ar.Errors) {
+	return m.upsertAll(tx.Context(), tx, ps, conflictColumns...)
+}
+
+func (m Comment) UpsertAllContext(ctx context.Context, ps []CommentParams, conflictColumns ...string) ([]*Comment, *ar.Errors) {
+	return m.upsertAll(ctx, nil, ps, conflictColumns...)
+}
+
+func (m Comment) upsertAll(ctx context.Context, tx *ar.Tx, ps []CommentParams, conflictColumns ...string) ([]*Comment, *ar.Errors) {
+	var ns []*Comment
+	var errs *ar.Errors
+	fn := func(tx *ar.Tx) error {
+		for _, p := range ps {
+			n, e := m.upsert(ctx, tx, p, conflictColumns...)
+			if e != nil {
+				errs = e
+				return e
+			}
+			ns = append(ns, n)
+		}
+		return nil
+	}
+
+	var err error
+	if tx != nil {
+		err = tx.Transaction(fn)
+	} else {
+		err = TransactionContext(ctx, fn)
+	}
+	if err != nil {
+		if errs == nil {
+			errs = &ar.Errors{}
+			errs.AddError("base", err)
+		}
+		return nil, errs
+	}
+	return ns, nil
+}
+
+func (m Comment) InsertAll(ps []CommentParams, validate ...bool) (int64, *ar.Errors) {
+	return m.insertAll(context.Background(), nil, ps, validate...)
+}
+
+func (m Comment) InsertAllTx(tx *ar.Tx, ps []CommentParams, validate ...bool) (int64, *ar.Errors) {
+	return m.insertAll(tx.Context(), tx, ps, validate...)
+}
+
+func (m Comment) InsertAllContext(ctx context.Context, ps []CommentParams, validate ...bool) (int64, *ar.Errors) {
+	return m.insertAll(ctx, nil, ps, validate...)
+}
+
+func (m Comment) insertAll(ctx context.Context, tx *ar.Tx, ps []CommentParams, validate ...bool) (int64, *ar.Errors) {
+	if len(ps) == 0 {
+		return 0, nil
+	}
+	rows := make([]map[string]interface{}, len(ps))
+	for i, p := range ps {
+		n := m.Build(p)
+		n.setCreateTimestamps()
+		if len(validate) == 0 || len(validate) > 0 && validate[0] {
+			if ok, errs := n.IsValid(); !ok {
+				return 0, errs
+			}
+		}
+		rows[i] = map[string]interface{}{
+			"post_id":    n.PostId,
+			"parent_id":  n.ParentId,
+			"body":       n.Body,
+			"created_at": n.CreatedAt,
+			"updated_at": n.UpdatedAt,
+		}
+	}
+
+	var affected int64
+	fn := func(tx *ar.Tx) error {
+		var err error
+		affected, err = newInsert(tx).Table("comments").Rows(rows...).ExecAllContext(ctx)
+		return err
+	}
+
+	var err error
+	if tx != nil {
+		err = tx.Transaction(fn)
+	} else {
+		err = TransactionContext(ctx, fn)
+	}
+	if err != nil {
+		errs := &ar.Errors{}
+		errs.AddError("base", err)
+		return 0, errs
+	}
+	return affected, nil
+}
+
+func (m *Comment) IsNewRecord() bool {
+	return ar.IsZero(m.Id)
+}
+
+func (m *Comment) IsPersistent() bool {
+	return !m.IsNewRecord()
+}
+
+func (m *Comment) Save(validate ...bool) (bool, *ar.Errors) {
+	return m.save(context.Background(), nil, validate...)
+}
+
+func (m *Comment) SaveTx(tx *ar.Tx, validate ...bool) (bool, *ar.Errors) {
+	return m.save(tx.Context(), tx, validate...)
+}
+
+func (m *Comment) SaveContext(ctx context.Context, validate ...bool) (bool, *ar.Errors) {
+	return m.save(ctx, nil, validate...)
+}
+
+func (m *Comment) save(ctx context.Context, tx *ar.Tx, validate ...bool) (bool, *ar.Errors) {
+	if len(validate) == 0 || len(validate) > 0 && validate[0] {
+		if ok, errs := m.IsValid(); !ok {
+			return false, errs
+		}
+	}
+	errs := &ar.Errors{}
+
+	if err := m.beforeSave(); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}
+	if m.IsNewRecord() {
+
+		if err := m.beforeCreate(); err != nil {
+			errs.AddError("base", err)
+			return false, errs
+		}
+		m.setCreateTimestamps()
+		ins := newInsert(tx).Table("comments").Params(map[string]interface{}{
+			"post_id":    m.PostId,
+			"parent_id":  m.ParentId,
+			"body":       m.Body,
+			"created_at": m.CreatedAt,
+			"updated_at": m.UpdatedAt,
+		})
+
+		if err := ins.ExecReturningContext(ctx, "id", &m.Id); err != nil {
+			errs.AddError("base", err)
+			return false, errs
+		}
+		m.takeSnapshot()
+
+		if err := m.afterCreate(); err != nil {
+			errs.AddError("base", err)
+			return false, errs
+		}
+	} else {
+
+		if err := m.beforeUpdate(); err != nil {
+			errs.AddError("base", err)
+			return false, errs
+		}
+		if columns := m.changedColumns(); len(columns) > 0 {
+			params := map[string]interface{}{}
+			for _, c := range columns {
+				params[c] = m.fieldValueByName(c)
+			}
+			m.setUpdateTimestamps()
+			params["updated_at"] = m.UpdatedAt
+
+			upd := newUpdate(tx).Table("comments").Params(params).Where("id", m.Id)
+
+			if _, err := upd.ExecContext(ctx); err != nil {
+				errs.AddError("base", err)
+				return false, errs
+			}
+			m.takeSnapshot()
+		}
+
+		if err := m.afterUpdate(); err != nil {
+			errs.AddError("base", err)
+			return false, errs
+		}
+	}
+
+	if err := m.afterSave(); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}
+	m.afterTransaction(tx)
+	return true, nil
+}
+
+func (m *Comment) Update(p CommentParams) (bool, *ar.Errors) {
+	return m.update(context.Background(), nil, p)
+}
+
+func (m *Comment) UpdateTx(tx *ar.Tx, p CommentParams) (bool, *ar.Errors) {
+	return m.update(tx.Context(), tx, p)
+}
+
+func (m *Comment) UpdateContext(ctx context.Context, p CommentParams) (bool, *ar.Errors) {
+	return m.update(ctx, nil, p)
+}
+
+func (m *Comment) update(ctx context.Context, tx *ar.Tx, p CommentParams, validate ...bool) (bool, *ar.Errors) {
+
+	if !ar.IsZero(p.Id) {
+		m.Id = p.Id
+	}
+	if !ar.IsZero(p.PostId) {
+		m.PostId = p.PostId
+	}
+	if !ar.IsZero(p.ParentId) {
+		m.ParentId = p.ParentId
+	}
+	if !ar.IsZero(p.Body) {
+		m.Body = p.Body
+	}
+	if !ar.IsZero(p.CreatedAt) {
+		m.CreatedAt = p.CreatedAt
+	}
+	if !ar.IsZero(p.UpdatedAt) {
+		m.UpdatedAt = p.UpdatedAt
+	}
+	return m.save(ctx, tx, validate...)
+}
+
+func (m *Comment) UpdateColumns(p CommentParams) (bool, *ar.Errors) {
+	return m.update(context.Background(), nil, p, false)
+}
+
+func (m *Comment) UpdateColumnsTx(tx *ar.Tx, p CommentParams) (bool, *ar.Errors) {
+	return m.update(tx.Context(), tx, p, false)
+}
+
+func (m *Comment) UpdateColumnsContext(ctx context.Context, p CommentParams) (bool, *ar.Errors) {
+	return m.update(ctx, nil, p, false)
+}
+
+func (m Comment) UpdateAll(params interface{}) (int64, error) {
+	return m.newRelation().UpdateAll(params)
+}
+
+func (m Comment) UpdateAllContext(ctx context.Context, params interface{}) (int64, error) {
+	return m.newRelation().UpdateAllContext(ctx, params)
+}
+
+func (r *CommentRelation) UpdateAll(params interface{}) (int64, error) {
+	return r.UpdateAllContext(context.Background(), params)
+}
+
+func (r *CommentRelation) UpdateAllContext(ctx context.Context, params interface{}) (int64, error) {
+	var p map[string]interface{}
+	switch params := params.(type) {
+	case map[string]interface{}:
+		p = params
+	case CommentParams:
+		p = map[string]interface{}{}
+		if !ar.IsZero(params.Id) {
+			p["id"] = params.Id
+		}
+		if !ar.IsZero(params.PostId) {
+			p["post_id"] = params.PostId
+		}
+		if !ar.IsZero(params.ParentId) {
+			p["parent_id"] = params.ParentId
+		}
+		if !ar.IsZero(params.Body) {
+			p["body"] = params.Body
+		}
+		if !ar.IsZero(params.CreatedAt) {
+			p["created_at"] = params.CreatedAt
+		}
+		if !ar.IsZero(params.UpdatedAt) {
+			p["updated_at"] = params.UpdatedAt
+		}
+	default:
+		return 0, fmt.Errorf("unsupported params type %T", params)
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if _, ok := p["updated_at"]; !ok {
+		q := map[string]interface{}{"updated_at": ar.Now()}
+		for k, v := range p {
+			q[k] = v
+		}
+		p = q
+	}
+	return r.Relation.UpdateAllContext(ctx, p)
+}
+
+func (m *Comment) Increment(column string, by int) (bool, *ar.Errors) {
+	return m.IncrementContext(context.Background(), column, by)
+}
+
+func (m *Comment) IncrementTx(tx *ar.Tx, column string, by int) (bool, *ar.Errors) {
+	return m.updateExpressions(tx.Context(), tx, map[string]interface{}{
+		column: query.Increment(column, by),
+	})
+}
+
+func (m *Comment) IncrementContext(ctx context.Context, column string, by int) (bool, *ar.Errors) {
+	return m.updateExpressions(ctx, nil, map[string]interface{}{
+		column: query.Increment(column, by),
+	})
+}
+
+func (m *Comment) Decrement(column string, by int) (bool, *ar.Errors) {
+	return m.IncrementContext(context.Background(), column, -by)
+}
+
+func (m *Comment) DecrementTx(tx *ar.Tx, column string, by int) (bool, *ar.Errors) {
+	return m.IncrementTx(tx, column, -by)
+}
+
+func (m *Comment) DecrementContext(ctx context.Context, column string, by int) (bool, *ar.Errors) {
+	return m.IncrementContext(ctx, column, -by)
+}
+
+func (m *Comment) Touch(columns ...string) (bool, *ar.Errors) {
+	return m.TouchContext(context.Background(), columns...)
+}
+
+func (m *Comment) TouchTx(tx *ar.Tx, columns ...string) (bool, *ar.Errors) {
+	return m.touch(tx.Context(), tx, columns)
+}
+
+func (m *Comment) TouchContext(ctx context.Context, columns ...string) (bool, *ar.Errors) {
+	return m.touch(ctx, nil, columns)
+}
+
+func (m *Comment) touch(ctx context.Context, tx *ar.Tx, columns []string) (bool, *ar.Errors) {
+	for _, c := range columns {
+		if !m.isTimeColumn(c) {
+			errs := &ar.Errors{}
+			errs.Add(c, "is not a timestamp")
+			return false, errs
+		}
+	}
+	return m.updateExpressions(ctx, tx, m.touchParams(columns))
+}
+
+func (m *Comment) isTimeColumn(name string) bool {
+	switch name {
+	case "created_at":
+		return true
+	case "updated_at":
+		return true
+	}
+	return false
+}
+
+func (m *Comment) touchParams(columns []string) map[string]interface{} {
+	now := ar.Now()
+	params := map[string]interface{}{}
+	for _, c := range columns {
+		params[c] = now
+	}
+	params["updated_at"] = now
+	return params
+}
+
+func (m *Comment) updateExpressions(ctx context.Context, tx *ar.Tx, params map[string]interface{}) (bool, *ar.Errors) {
+	errs := &ar.Errors{}
+	if len(params) == 0 {
+		return true, nil
+	}
+	columns := []string{}
+	for c := range params {
+		if !m.isColumnName(c) {
+			errs.Add(c, "is not a column")
+			return false, errs
+		}
+		columns = append(columns, c)
+	}
+
+	upd := newUpdate(tx).Table("comments").Params(params).Where("id", m.Id)
+	if _, err := upd.ExecContext(ctx); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}
+
+	rel := newRelation(tx).Table("comments").Columns(columns...).Where("id", m.Id)
+	if err := rel.QueryRowContext(ctx, m.fieldPtrsByName(columns)...); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}
+	m.takeSnapshot(columns...)
+	return true, nil
+}
+
+func (m *Comment) Destroy() (bool, *ar.Errors) {
+	return m.delete(context.Background(), nil, false)
+}
+
+func (m *Comment) DestroyTx(tx *ar.Tx) (bool, *ar.Errors) {
+	return m.delete(tx.Context(), tx, false)
+}
+
+func (m *Comment) DestroyContext(ctx context.Context) (bool, *ar.Errors) {
+	return m.delete(ctx, nil, false)
+}
+
+func (m *Comment) Delete() (bool, *ar.Errors) {
+	return m.delete(context.Background(), nil, false)
+}
+
+func (m *Comment) DeleteTx(tx *ar.Tx) (bool, *ar.Errors) {
+	return m.delete(tx.Context(), tx, false)
+}
+
+func (m *Comment) DeleteContext(ctx context.Context) (bool, *ar.Errors) {
+	return m.delete(ctx, nil, false)
+}
+
+func (m *Comment) delete(ctx context.Context, tx *ar.Tx, really bool) (bool, *ar.Errors) {
+	errs := &ar.Errors{}
+
+	if err := m.beforeDestroy(); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}
+	if _, err := newDelete(tx).Table("comments").Where("id", m.Id).ExecContext(ctx); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}
+
+	if err := m.afterDestroy(); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}
+	m.afterTransaction(tx)
+	return true, nil
+}
+
+func (m Comment) DeleteAll() (int64, error) {
+	return m.newRelation().DeleteAll()
+}
+
+func (m Comment) DeleteAllContext(ctx context.Context) (int64, error) {
+	return m.newRelation().DeleteAllContext(ctx)
+}
+
+func (m *Comment) afterTransaction(tx *ar.Tx) {
+	if tx == nil {
+		m.afterCommit()
+		return
+	}
+	tx.AfterCommit(m.afterCommit)
+	tx.AfterRollback(m.afterRollback)
+}
+
+func (r *CommentRelation) Query() ([]*Comment, error) {
+	return r.QueryContext(context.Background())
+}
+
+func (r *CommentRelation) QueryContext(ctx context.Context) ([]*Comment, error) {
+	rows, err := r.Relation.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*Comment{}
+	for rows.Next() {
+		row := &Comment{}
+		err := rows.Scan(row.fieldPtrsByName(r.Relation.GetColumns())...)
+		if err != nil {
+			return nil, err
+		}
+		row.takeSnapshot()
+		results = append(results, row)
+	}
+	return results, nil
+}
+
+func (r *CommentRelation) QueryRow() (*Comment, error) {
+	return r.QueryRowContext(context.Background())
+}
+
+func (r *CommentRelation) QueryRowContext(ctx context.Context) (*Comment, error) {
+	row := &Comment{}
+	err := r.Relation.QueryRowContext(ctx, row.fieldPtrsByName(r.Relation.GetColumns())...)
+	if err != nil {
+		return nil, err
+	}
+	row.takeSnapshot()
+	return row, nil
+}
+
+func (m Comment) Exists() bool {
+	return m.newRelation().Exists()
+}
+
+func (m Comment) ExistsContext(ctx context.Context) bool {
+	return m.newRelation().ExistsContext(ctx)
+}
+
+func (m Comment) Count(column ...string) int {
+	return m.newRelation().Count(column...)
+}
+
+func (m Comment) CountContext(ctx context.Context, column ...string) int {
+	return m.newRelation().CountContext(ctx, column...)
+}
+
+func (m Comment) All() *CommentRelation {
+	return m.newRelation().All()
+}
+
+func (r *CommentRelation) All() *CommentRelation {
+	return r
+}
+
+func (m *Comment) fieldValueByName(name string) interface{} {
+	switch name {
+	case "id", "comments.id":
+		return m.Id
+	case "post_id", "comments.post_id":
+		return m.PostId
+	case "parent_id", "comments.parent_id":
+		return m.ParentId
+	case "body", "comments.body":
+		return m.Body
+	case "created_at", "comments.created_at":
+		return m.CreatedAt
+	case "updated_at", "comments.updated_at":
+		return m.UpdatedAt
+	default:
+		return ""
+	}
+}
+
+func (m *Comment) fieldPtrByName(name string) interface{} {
+	switch name {
+	case "id", "comments.id":
+		return &m.Id
+	case "post_id", "comments.post_id":
+		return &m.PostId
+	case "parent_id", "comments.parent_id":
+		return &m.ParentId
+	case "body", "comments.body":
+		return &m.Body
+	case "created_at", "comments.created_at":
+		return &m.CreatedAt
+	case "updated_at", "comments.updated_at":
+		return &m.UpdatedAt
+	default:
+		return nil
+	}
+}
+
+func (m *Comment) fieldPtrsByName(names []string) []interface{} {
+	fields := []interface{}{}
+	for _, n := range names {
+		f := m.fieldPtrByName(n)
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func (m *Comment) isColumnName(name string) bool {
+	for _, c := range m.columnNames() {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Comment) columnNames() []string {
+	return []string{
+		"id",
+		"post_id",
+		"parent_id",
+		"body",
+		"created_at",
+		"updated_at",
+	}
+}
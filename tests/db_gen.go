@@ -1,10 +1,79 @@
 // generated by argen; DO NOT EDIT
 package tests
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+
+	"github.com/monochromegane/argen"
+	"github.com/monochromegane/argen/query"
+)
 
 var db *sql.DB
+var dialect query.Dialect
+var stmtCache *ar.StmtCache
+var strictLock bool
 
-func Use(DB *sql.DB) {
+func Use(DB *sql.DB, d ...query.Dialect) {
 	db = DB
+	if len(d) > 0 {
+		dialect = d[0]
+	}
+}
+
+func UseStmtCache(size int) {
+	if stmtCache != nil {
+		stmtCache.Close()
+	}
+	stmtCache = nil
+	if size > 0 {
+		stmtCache = ar.NewStmtCache(db, size)
+	}
+}
+
+func UseStrictLock(strict bool) {
+	strictLock = strict
+}
+
+func Transaction(fn func(tx *ar.Tx) error) error {
+	return ar.Transaction(db, logger, fn)
+}
+
+func TransactionContext(ctx context.Context, fn func(tx *ar.Tx) error) error {
+	return ar.TransactionContext(ctx, db, logger, fn)
+}
+
+func TransactionTx(tx *ar.Tx, fn func(tx *ar.Tx) error) error {
+	if tx != nil {
+		return tx.Transaction(fn)
+	}
+	return Transaction(fn)
+}
+
+func newRelation(tx *ar.Tx) *ar.Relation {
+	if tx != nil {
+		return ar.NewRelationTx(tx, logger).Dialect(dialect).Cache(stmtCache).StrictLock(strictLock)
+	}
+	return ar.NewRelation(db, logger).Dialect(dialect).Cache(stmtCache).StrictLock(strictLock)
+}
+
+func newInsert(tx *ar.Tx) *ar.Insert {
+	if tx != nil {
+		return ar.NewInsertTx(tx, logger).Dialect(dialect).Cache(stmtCache)
+	}
+	return ar.NewInsert(db, logger).Dialect(dialect).Cache(stmtCache)
+}
+
+func newUpdate(tx *ar.Tx) *ar.Update {
+	if tx != nil {
+		return ar.NewUpdateTx(tx, logger).Dialect(dialect).Cache(stmtCache)
+	}
+	return ar.NewUpdate(db, logger).Dialect(dialect).Cache(stmtCache)
+}
+
+func newDelete(tx *ar.Tx) *ar.Delete {
+	if tx != nil {
+		return ar.NewDeleteTx(tx, logger).Dialect(dialect).Cache(stmtCache)
+	}
+	return ar.NewDelete(db, logger).Dialect(dialect).Cache(stmtCache)
 }
@@ -1,15 +1,22 @@
 package tests
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/monochromegane/argen"
+	"github.com/monochromegane/argen/query"
 )
 
 func TestMain(m *testing.M) {
@@ -19,7 +26,7 @@ func TestMain(m *testing.M) {
 	}
 	defer db.Close()
 
-	Use(db)
+	Use(db, testDialect())
 	LogMode(true)
 	for _, q := range testTables() {
 		_, err = db.Exec(q)
@@ -103,6 +110,74 @@ func TestWhere(t *testing.T) {
 	assertEqualStruct(t, expect, u)
 }
 
+func TestOr(t *testing.T) {
+	for _, name := range []string{"test1", "test2", "test3"} {
+		u := &User{Name: name}
+		u.Save()
+	}
+	defer User{}.DeleteAll()
+
+	users, err := User{}.Where("name", "test1").Or("name", "test2").Order("name", "ASC").Query()
+	assertError(t, err)
+	assertNames(t, []string{"test1", "test2"}, users)
+
+	users, err = User{}.Where("name", "test1").Or(User{}.Where("name", "test3").And("age", 0)).Order("name", "ASC").Query()
+	assertError(t, err)
+	assertNames(t, []string{"test1", "test3"}, users)
+}
+
+func TestNot(t *testing.T) {
+	for _, name := range []string{"test1", "test2", "test3"} {
+		u := &User{Name: name}
+		u.Save()
+	}
+	defer User{}.DeleteAll()
+
+	users, err := User{}.Not(User{}.Where("name", "test1").Or("name", "test2")).Query()
+	assertError(t, err)
+	assertNames(t, []string{"test3"}, users)
+}
+
+func TestOperators(t *testing.T) {
+	for i, name := range []string{"test1", "test2", "test_3"} {
+		u := &User{Name: name, Age: 20 + i}
+		u.Save()
+	}
+	defer User{}.DeleteAll()
+
+	users, err := User{}.Where("age", []int{20, 22}).Order("name", "ASC").Query()
+	assertError(t, err)
+	assertNames(t, []string{"test1", "test_3"}, users)
+
+	users, err = User{}.Where("age", "IN", []int{}).Query()
+	assertError(t, err)
+	assertNames(t, []string{}, users)
+
+	users, err = User{}.Where("age", "BETWEEN", 21, 22).Order("name", "ASC").Query()
+	assertError(t, err)
+	assertNames(t, []string{"test2", "test_3"}, users)
+
+	users, err = User{}.Where("name", "LIKE", query.StartsWith("test_")).Query()
+	assertError(t, err)
+	assertNames(t, []string{"test_3"}, users)
+
+	users, err = User{}.Where("name", nil).Query()
+	assertError(t, err)
+	assertNames(t, []string{}, users)
+}
+
+func assertNames(t *testing.T, expects []string, users []*User) {
+	if len(users) != len(expects) {
+		t.Errorf("record count should be %v, but %v", len(expects), len(users))
+		return
+	}
+	for i, u := range users {
+		if u.Name != expects[i] {
+			t.Errorf("column value should be %v, but %v", expects[i], u.Name)
+		}
+	}
+}
+
 func TestOrder(t *testing.T) {
 	expects := []string{"test1", "test2"}
 	for _, name := range expects {
@@ -209,7 +284,7 @@ func TestIsNewRecordAndIsPresistent(t *testing.T) {
 }
 
 func TestSaveWithInvalidData(t *testing.T) {
-	defer Post{}.DeleteAll()
+	defer Post{}.Unscoped().DeleteAll()
 
 	// OnCreate
 	p := &Post{Name: "invalid"}
@@ -288,6 +363,307 @@ func TestUpdateColumns(t *testing.T) {
 	}
 }
 
+func TestOptimisticLock(t *testing.T) {
+	defer Post{}.Unscoped().DeleteAll()
+
+	p, errs := Post{}.Create(PostParams{UserId: 1, Name: "name"})
+	assertErrors(t, errs)
+	if p.LockVersion != 0 {
+		t.Errorf("lock version should be 0, but %v", p.LockVersion)
+	}
+
+	stale, _ := Post{}.Find(p.Id)
+
+	_, errs = p.Save()
+	assertErrors(t, errs)
+	if p.LockVersion != 1 {
+		t.Errorf("lock version should be 1, but %v", p.LockVersion)
+	}
+
+	ok, errs := stale.Save()
+	if ok || errs == nil || !errs.Is(ar.ErrStaleObject) {
+		t.Errorf("save should fail with %v, but %v", ar.ErrStaleObject, errs)
+	}
+	if stale.LockVersion != 0 {
+		t.Errorf("lock version should be 0, but %v", stale.LockVersion)
+	}
+
+	assertError(t, stale.Reload())
+	_, errs = stale.Update(PostParams{UserId: 2})
+	assertErrors(t, errs)
+
+	actual, _ := Post{}.Find(p.Id)
+	assertEqualStruct(t, stale, actual)
+	if actual.LockVersion != 2 {
+		t.Errorf("lock version should be 2, but %v", actual.LockVersion)
+	}
+
+	upserted, errs := Post{}.Upsert(PostParams{Id: p.Id, UserId: 3, Name: "name"}, "id")
+	assertErrors(t, errs)
+	actual, _ = Post{}.Find(p.Id)
+	if actual.UserId != 3 || actual.LockVersion != 2 {
+		t.Errorf("upsert should keep lock version 2, but %v", actual)
+	}
+	assertEqualStruct(t, actual, upserted)
+	_, errs = upserted.Save()
+	assertErrors(t, errs)
+}
+
+func TestDirtyTracking(t *testing.T) {
+	defer User{}.DeleteAll()
+
+	u, _ := User{}.Create(UserParams{Name: "test", Age: 20})
+	u, err := User{}.Find(u.Id)
+	assertError(t, err)
+	if u.Changed() {
+		t.Errorf("loaded record should not be changed, but %v", u.Changes())
+	}
+
+	u.Name = "test2"
+	if !u.Changed() || !u.WasChanged("name") || u.WasChanged("age") {
+		t.Errorf("only name should be changed, but %v", u.Changes())
+	}
+	expect := map[string]ar.Change{"name": {Old: "test", New: "test2"}}
+	assertEqualStruct(t, expect, u.Changes())
+
+	u.RestoreAttributes()
+	if u.Name != "test" || u.Changed() {
+		t.Errorf("attributes should be restored, but %v", u.Changes())
+	}
+
+	var buf bytes.Buffer
+	logger.Logger.SetOutput(&buf)
+	defer logger.Logger.SetOutput(os.Stdout)
+
+	_, errs := u.Save()
+	assertErrors(t, errs)
+	if buf.Len() != 0 {
+		t.Errorf("no query should be issued, but %v", buf.String())
+	}
+
+	u.Age = 21
+	_, errs = u.Save()
+	assertErrors(t, errs)
+	if !strings.Contains(buf.String(), "UPDATE users SET age = ? WHERE id = ?;") {
+		t.Errorf("only age should be updated, but %v", buf.String())
+	}
+	if u.Changed() {
+		t.Errorf("saved record should not be changed, but %v", u.Changes())
+	}
+
+	copied := *u
+	u.Age = 22
+	_, errs = u.Save()
+	assertErrors(t, errs)
+	if copied.Changed() {
+		t.Errorf("copied record should not be changed by saving the original, but %v", copied.Changes())
+	}
+
+	actual, _ := User{}.Find(u.Id)
+	assertEqualStruct(t, u, actual)
+}
+
+func TestTimestamps(t *testing.T) {
+	defer Comment{}.DeleteAll()
+	defer ar.SetClock(nil)
+
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ar.SetClock(func() time.Time { return created })
+
+	c, errs := Comment{}.Create(CommentParams{Body: "body"})
+	assertErrors(t, errs)
+	if !c.CreatedAt.Equal(created) || !c.UpdatedAt.Equal(created) {
+		t.Errorf("timestamps should be %v, but %v and %v", created, c.CreatedAt, c.UpdatedAt)
+	}
+
+	updated := created.Add(time.Hour)
+	ar.SetClock(func() time.Time { return updated })
+
+	c.Body = "updated"
+	_, errs = c.Save()
+	assertErrors(t, errs)
+	actual, err := Comment{}.Find(c.Id)
+	assertError(t, err)
+	if !actual.CreatedAt.Equal(created) || !actual.UpdatedAt.Equal(updated) {
+		t.Errorf("timestamps should be %v and %v, but %v and %v", created, updated, actual.CreatedAt, actual.UpdatedAt)
+	}
+
+	touched := updated.Add(time.Hour)
+	ar.SetClock(func() time.Time { return touched })
+
+	if ok, errs := c.Touch(); !ok {
+		t.Fatalf("touch should succeed, but %v", errs)
+	}
+	if !c.UpdatedAt.Equal(touched) {
+		t.Errorf("updated_at should be %v, but %v", touched, c.UpdatedAt)
+	}
+
+	upserted, errs := Comment{}.Upsert(CommentParams{Id: c.Id, Body: "upserted"}, "id")
+	assertErrors(t, errs)
+	if !upserted.CreatedAt.Equal(created) || !upserted.UpdatedAt.Equal(touched) {
+		t.Errorf("timestamps should be %v and %v, but %v and %v", created, touched, upserted.CreatedAt, upserted.UpdatedAt)
+	}
+
+	retouched := touched.Add(time.Hour)
+	ar.SetClock(func() time.Time { return retouched })
+
+	if ok, errs := c.Touch("created_at"); !ok {
+		t.Fatalf("touch should succeed, but %v", errs)
+	}
+	actual, _ = Comment{}.Find(c.Id)
+	if !actual.CreatedAt.Equal(retouched) || !actual.UpdatedAt.Equal(retouched) {
+		t.Errorf("timestamps should be %v, but %v and %v", retouched, actual.CreatedAt, actual.UpdatedAt)
+	}
+
+	all := retouched.Add(time.Hour)
+	ar.SetClock(func() time.Time { return all })
+
+	_, err = Comment{}.Where("id", c.Id).UpdateAll(CommentParams{Body: "all"})
+	assertError(t, err)
+	actual, _ = Comment{}.Find(c.Id)
+	if !actual.CreatedAt.Equal(retouched) || !actual.UpdatedAt.Equal(all) {
+		t.Errorf("timestamps should be %v and %v, but %v and %v", retouched, all, actual.CreatedAt, actual.UpdatedAt)
+	}
+}
+
+func TestSoftDelete(t *testing.T) {
+	defer User{}.DeleteAll()
+	defer Post{}.Unscoped().DeleteAll()
+
+	u, _ := User{}.Create(UserParams{Name: "test"})
+	p1, _ := Post{}.Create(PostParams{UserId: u.Id, Name: "name"})
+	p2, _ := Post{}.Create(PostParams{UserId: u.Id, Name: "name"})
+
+	_, errs := p1.Delete()
+	assertErrors(t, errs)
+	if !p1.IsDeleted() {
+		t.Errorf("post should be marked as deleted")
+	}
+
+	posts, err := u.Posts()
+	assertError(t, err)
+	if len(posts) != 1 || posts[0].Id != p2.Id {
+		t.Errorf("only post %v should be found, but %v", p2.Id, posts)
+	}
+	_, err = Post{}.Find(p1.Id)
+	if err != sql.ErrNoRows {
+		t.Errorf("deleted post should not be found, but %v", err)
+	}
+	posts, err = Post{}.Where("user_id", 99).Or("name", "name").Query()
+	assertError(t, err)
+	if len(posts) != 1 || posts[0].Id != p2.Id {
+		t.Errorf("only post %v should be found, but %v", p2.Id, posts)
+	}
+	count := Post{}.WithDeleted().Count()
+	if count != 2 {
+		t.Errorf("record count with deleted should be 2, but %v", count)
+	}
+	deleted, err := Post{}.OnlyDeleted().Query()
+	assertError(t, err)
+	if len(deleted) != 1 || deleted[0].Id != p1.Id || deleted[0].DeletedAt == nil {
+		t.Errorf("only post %v should be deleted, but %v", p1.Id, deleted)
+	}
+
+	_, errs = p1.Restore()
+	assertErrors(t, errs)
+	count = Post{}.Count()
+	if count != 2 {
+		t.Errorf("record count should be 2, but %v", count)
+	}
+
+	_, errs = p2.ReallyDestroy()
+	assertErrors(t, errs)
+	count = Post{}.WithDeleted().Count()
+	if count != 1 {
+		t.Errorf("record count with deleted should be 1, but %v", count)
+	}
+
+	Post{}.Create(PostParams{UserId: u.Id, Name: "name"})
+	p1.Delete()
+	affected, err := Post{}.DeleteAll()
+	assertError(t, err)
+	if affected != 1 {
+		t.Errorf("delete all should not remove deleted posts, but %v", affected)
+	}
+	count = Post{}.WithDeleted().Count()
+	if count != 1 {
+		t.Errorf("record count with deleted should be 1, but %v", count)
+	}
+}
+
+func TestUnscope(t *testing.T) {
+	defer Post{}.Unscoped().DeleteAll()
+
+	p1, _ := Post{}.Create(PostParams{UserId: 1, Name: "name"})
+	p2, _ := Post{}.Create(PostParams{UserId: 2, Name: "name"})
+	p1.Delete()
+
+	count := Post{}.Unscoped().Count()
+	if count != 2 {
+		t.Errorf("record count without scope should be 2, but %v", count)
+	}
+	count = Post{}.Where("user_id", 1).Unscoped().Count()
+	if count != 2 {
+		t.Errorf("record count without scope should be 2, but %v", count)
+	}
+	count = Post{}.Where("user_id", 1).Unscope(query.WhereClause).Count()
+	if count != 2 {
+		t.Errorf("record count without where should be 2, but %v", count)
+	}
+
+	posts, err := Post{}.Rewhere("posts.deleted_at", "IS NOT", nil).Query()
+	assertError(t, err)
+	if len(posts) != 1 || posts[0].Id != p1.Id {
+		t.Errorf("only post %v should be found, but %v", p1.Id, posts)
+	}
+
+	posts, err = Post{}.Unscoped().ReverseOrder().Query()
+	assertError(t, err)
+	if len(posts) != 2 || posts[0].Id != p2.Id {
+		t.Errorf("posts should be ordered by id desc, but %v", posts)
+	}
+
+	posts, err = Post{}.Unscoped().Order("user_id", "DESC").Reorder("id", "ASC").ReverseOrder().Limit(1).Query()
+	assertError(t, err)
+	if len(posts) != 1 || posts[0].Id != p2.Id {
+		t.Errorf("post %v should be found, but %v", p2.Id, posts)
+	}
+}
+
+func TestRelationClone(t *testing.T) {
+	defer User{}.DeleteAll()
+
+	User{}.Create(UserParams{Name: "test1", Age: 20})
+	User{}.Create(UserParams{Name: "test2", Age: 30})
+	User{}.Create(UserParams{Name: "test3", Age: 40})
+
+	base := User{}.Where("age", ">", 10).Order("age", "ASC")
+
+	count := base.Count()
+	if count != 3 {
+		t.Errorf("record count should be 3, but %v", count)
+	}
+	if !base.Exists() {
+		t.Errorf("record should exist")
+	}
+	first, err := base.First()
+	assertError(t, err)
+	if first.Name != "test1" {
+		t.Errorf("first record should be test1, but %v", first.Name)
+	}
+
+	limited, err := base.Clone().Where("age", "<", 40).Limit(1).ReverseOrder().Query()
+	assertError(t, err)
+	assertNames(t, []string{"test2"}, limited)
+
+	users, err := base.Query()
+	assertError(t, err)
+	assertNames(t, []string{"test1", "test2", "test3"}, users)
+	if users[0].Age != 20 {
+		t.Errorf("all columns should be selected, but %v", users[0])
+	}
+}
+
 func TestDelete(t *testing.T) {
 	defer User{}.DeleteAll()
 
@@ -319,7 +695,7 @@ func TestScope(t *testing.T) {
 func TestHasMany(t *testing.T) {
 	defer func() {
 		User{}.DeleteAll()
-		Post{}.DeleteAll()
+		Post{}.Unscoped().DeleteAll()
 	}()
 
 	u, _ := User{}.Create(UserParams{Name: "test1"})
@@ -337,7 +713,7 @@ func TestHasMany(t *testing.T) {
 func TestBelongsTo(t *testing.T) {
 	defer func() {
 		User{}.DeleteAll()
-		Post{}.DeleteAll()
+		Post{}.Unscoped().DeleteAll()
 	}()
 
 	expect, _ := User{}.Create(UserParams{Name: "test1"})
@@ -351,7 +727,7 @@ func TestBelongsTo(t *testing.T) {
 func TestJoins(t *testing.T) {
 	defer func() {
 		User{}.DeleteAll()
-		Post{}.DeleteAll()
+		Post{}.Unscoped().DeleteAll()
 	}()
 
 	// User joins posts
@@ -377,6 +753,480 @@ func TestJoins(t *testing.T) {
 	assertEqualStruct(t, posts[0], p1)
 }
 
+func TestSubquery(t *testing.T) {
+	defer func() {
+		User{}.DeleteAll()
+		Post{}.Unscoped().DeleteAll()
+	}()
+
+	u1, _ := User{}.Create(UserParams{Name: "test1", Age: 20})
+	u2, _ := User{}.Create(UserParams{Name: "test2", Age: 30})
+	User{}.Create(UserParams{Name: "test3", Age: 40})
+	Post{}.Create(PostParams{UserId: u1.Id, Name: "name1"})
+	Post{}.Create(PostParams{UserId: u2.Id, Name: "name2"})
+
+	users, err := User{}.Where("id", "IN", Post{}.Select("user_id").Where("name", "name2")).Query()
+	assertError(t, err)
+	if len(users) != 1 {
+		t.Fatalf("record count should be 1, but %v", len(users))
+	}
+	assertEqualStruct(t, users[0], u2)
+
+	users, err = User{}.WhereExists(Post{}.Select("1").Where("posts.user_id = users.id")).Order("id", "ASC").Query()
+	assertError(t, err)
+	if len(users) != 2 {
+		t.Fatalf("record count should be 2, but %v", len(users))
+	}
+	assertEqualStruct(t, users[0], u1)
+	assertEqualStruct(t, users[1], u2)
+
+	users, err = User{}.From(User{}.Where("age", ">", 20), "users").Where("age", "<", 40).Query()
+	assertError(t, err)
+	if len(users) != 1 {
+		t.Fatalf("record count should be 1, but %v", len(users))
+	}
+	assertEqualStruct(t, users[0], u2)
+}
+
+func TestCompound(t *testing.T) {
+	defer User{}.DeleteAll()
+
+	u1, _ := User{}.Create(UserParams{Name: "test1", Age: 20})
+	u2, _ := User{}.Create(UserParams{Name: "test2", Age: 30})
+	u3, _ := User{}.Create(UserParams{Name: "test3", Age: 40})
+
+	users, err := User{}.Where("age", "<", 25).Union(User{}.Where("age", ">", 35)).Order("id", "DESC").Query()
+	assertError(t, err)
+	if len(users) != 2 {
+		t.Fatalf("record count should be 2, but %v", len(users))
+	}
+	assertEqualStruct(t, users[0], u3)
+	assertEqualStruct(t, users[1], u1)
+
+	union := User{}.Where("age", "<", 25).Union(User{}.Where("age", ">", 35))
+	count := union.Count()
+	if count != 2 {
+		t.Errorf("record count should be 2, but %v", count)
+	}
+	if !union.Exists() {
+		t.Errorf("union should exist")
+	}
+
+	users, err = User{}.Where("age", "<", 25).Union(User{}.Order("age", "DESC").Limit(1)).Order("id", "ASC").Query()
+	assertError(t, err)
+	if len(users) != 2 {
+		t.Fatalf("record count should be 2, but %v", len(users))
+	}
+	assertEqualStruct(t, users[0], u1)
+	assertEqualStruct(t, users[1], u3)
+
+	users, err = User{}.Where("age", ">", 25).Except(User{}.Where("age", ">", 35)).Query()
+	assertError(t, err)
+	if len(users) != 1 {
+		t.Fatalf("record count should be 1, but %v", len(users))
+	}
+	assertEqualStruct(t, users[0], u2)
+
+	users, err = User{}.Where("age", ">", 25).Intersect(User{}.Where("age", "<", 35)).Query()
+	assertError(t, err)
+	if len(users) != 1 {
+		t.Fatalf("record count should be 1, but %v", len(users))
+	}
+	assertEqualStruct(t, users[0], u2)
+}
+
+func TestWithRecursive(t *testing.T) {
+	defer Comment{}.DeleteAll()
+
+	c1, _ := Comment{}.Create(CommentParams{PostId: 1, Body: "root"})
+	c2, _ := Comment{}.Create(CommentParams{PostId: 1, ParentId: c1.Id, Body: "reply"})
+	c3, _ := Comment{}.Create(CommentParams{PostId: 1, ParentId: c2.Id, Body: "reply to reply"})
+	Comment{}.Create(CommentParams{PostId: 1, Body: "other root"})
+
+	thread := Comment{}.Select("id").Where("id", c2.Id).UnionAll(
+		Comment{}.Select("id").Joins("INNER JOIN thread ON comments.parent_id = thread.id"),
+	)
+	comments, err := Comment{}.WithRecursive("thread(id)", thread).Joins("INNER JOIN thread ON thread.id = comments.id").Order("comments.id", "ASC").Query()
+	assertError(t, err)
+	if len(comments) != 2 {
+		t.Fatalf("record count should be 2, but %v", len(comments))
+	}
+	assertEqualStruct(t, comments[0], c2)
+	assertEqualStruct(t, comments[1], c3)
+
+	comments, err = Comment{}.With("roots", Comment{}.Select("id").Where("parent_id", 0)).Where("parent_id", "IN", (&query.Select{}).Table("roots").Columns("id")).Query()
+	assertError(t, err)
+	if len(comments) != 1 {
+		t.Fatalf("record count should be 1, but %v", len(comments))
+	}
+	assertEqualStruct(t, comments[0], c2)
+}
+
+type returningDialect struct {
+	query.Dialect
+}
+
+func (returningDialect) SupportsReturning() bool {
+	return true
+}
+
+func TestReturning(t *testing.T) {
+	if os.Getenv("DB") == "mysql" {
+		t.Skip("mysql does not support RETURNING")
+	}
+	Use(db, returningDialect{query.SQLite})
+	defer Use(db, testDialect())
+	defer User{}.DeleteAll()
+
+	u, errs := User{}.Create(UserParams{Name: "test", Age: 20})
+	if errs != nil {
+		t.Fatalf("create should succeed, but %v", errs)
+	}
+	if u.Id == 0 {
+		t.Fatalf("primary key should be populated")
+	}
+	expect, _ := User{}.First()
+	assertEqualStruct(t, u, expect)
+
+	rows, err := newUpdate(nil).Table("users").Params(map[string]interface{}{"age": 21}).Where("id", u.Id).Returning("id", "age").Query()
+	assertError(t, err)
+	defer rows.Close()
+	var id, age int
+	for rows.Next() {
+		rows.Scan(&id, &age)
+	}
+	if id != u.Id || age != 21 {
+		t.Errorf("returning should be [%v 21], but [%v %v]", u.Id, id, age)
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	index := "create unique index index_users_on_name on users (name);"
+	if os.Getenv("DB") == "mysql" {
+		index = "create unique index index_users_on_name on users (name(255));"
+	}
+	if _, err := db.Exec(index); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		User{}.DeleteAll()
+		if os.Getenv("DB") == "mysql" {
+			db.Exec("drop index index_users_on_name on users;")
+		} else {
+			db.Exec("drop index index_users_on_name;")
+		}
+	}()
+
+	u1, errs := User{}.Upsert(UserParams{Name: "test", Age: 20}, "name")
+	if errs != nil {
+		t.Fatalf("upsert should succeed, but %v", errs)
+	}
+	u2, errs := User{}.Upsert(UserParams{Name: "test", Age: 30}, "name")
+	if errs != nil {
+		t.Fatalf("upsert should succeed, but %v", errs)
+	}
+	if u1.Id == 0 || u1.Id != u2.Id {
+		t.Errorf("upsert should keep primary key %v, but %v", u1.Id, u2.Id)
+	}
+	expect, _ := User{}.Find(u1.Id)
+	assertEqualStruct(t, u2, expect)
+
+	users, errs := User{}.UpsertAll([]UserParams{{Name: "test", Age: 40}, {Name: "test2", Age: 50}}, "name")
+	if errs != nil {
+		t.Fatalf("upsert all should succeed, but %v", errs)
+	}
+	count := User{}.Count()
+	if count != 2 {
+		t.Fatalf("record count should be 2, but %v", count)
+	}
+	for _, u := range users {
+		expect, _ := User{}.Find(u.Id)
+		assertEqualStruct(t, u, expect)
+	}
+	if users[0].Id != u1.Id || users[0].Age != 40 {
+		t.Errorf("upsert all should update %v, but %v", u1.Id, users[0])
+	}
+
+	u3, errs := User{}.Upsert(UserParams{Id: u1.Id, Name: "test3", Age: 60}, "id")
+	if errs != nil {
+		t.Fatalf("upsert should succeed, but %v", errs)
+	}
+	if u3.Id != u1.Id {
+		t.Errorf("upsert should keep primary key %v, but %v", u1.Id, u3.Id)
+	}
+	expect, _ = User{}.Find(u1.Id)
+	if expect.Name != "test3" || expect.Age != 60 {
+		t.Errorf("upsert should update by primary key, but %v", expect)
+	}
+
+	_, errs = User{}.Upsert(UserParams{Name: "test4"}, "id")
+	if errs == nil {
+		t.Errorf("upsert without primary key should fail")
+	}
+	_, errs = User{}.Upsert(UserParams{Name: "test4"}, "unknown")
+	if errs == nil {
+		t.Errorf("upsert with unknown conflict column should fail")
+	}
+	count = User{}.Count()
+	if count != 2 {
+		t.Errorf("record count should be 2, but %v", count)
+	}
+}
+
+func TestInsertAll(t *testing.T) {
+	defer User{}.DeleteAll()
+
+	ps := make([]UserParams, 1000)
+	for i := range ps {
+		ps[i] = UserParams{Name: fmt.Sprintf("test%d", i), Age: i}
+	}
+	affected, errs := User{}.InsertAll(ps)
+	if errs != nil {
+		t.Fatalf("insert all should succeed, but %v", errs)
+	}
+	if affected != 1000 {
+		t.Errorf("affected rows should be 1000, but %v", affected)
+	}
+	count := User{}.Count()
+	if count != 1000 {
+		t.Errorf("record count should be 1000, but %v", count)
+	}
+	u, _ := User{}.Order("age", "DESC").First()
+	if u.Name != "test999" || u.Age != 999 {
+		t.Errorf("last record should be test999, but %v", u)
+	}
+
+	defer Post{}.Unscoped().DeleteAll()
+	_, errs = Post{}.InsertAll([]PostParams{{Name: "name"}, {Name: "invalid"}})
+	if errs == nil {
+		t.Errorf("insert all should fail validation")
+	}
+	count = Post{}.Count()
+	if count != 0 {
+		t.Errorf("invalid batch should not be inserted, but %v", count)
+	}
+	_, errs = Post{}.InsertAll([]PostParams{{Name: "name"}, {Name: "invalid"}}, false)
+	if errs != nil {
+		t.Errorf("insert all without validation should succeed, but %v", errs)
+	}
+	count = Post{}.Count()
+	if count != 2 {
+		t.Errorf("record count should be 2, but %v", count)
+	}
+}
+
+func TestRelationUpdateAllAndDeleteAll(t *testing.T) {
+	defer func() {
+		User{}.DeleteAll()
+		Post{}.Unscoped().DeleteAll()
+	}()
+
+	u1, _ := User{}.Create(UserParams{Name: "test1", Age: 10})
+	u2, _ := User{}.Create(UserParams{Name: "test2", Age: 20})
+	u3, _ := User{}.Create(UserParams{Name: "test3", Age: 30})
+	Post{}.Create(PostParams{UserId: u2.Id, Name: "name"})
+
+	affected, err := User{}.Where("age", "<", 25).UpdateAll(UserParams{Name: "young"})
+	assertError(t, err)
+	if affected != 2 {
+		t.Errorf("affected rows should be 2, but %v", affected)
+	}
+	count := User{}.Where("name", "young").Count()
+	if count != 2 {
+		t.Errorf("record count should be 2, but %v", count)
+	}
+
+	affected, err = User{}.JoinsPosts().UpdateAll(map[string]interface{}{"age": 21})
+	assertError(t, err)
+	if affected != 1 {
+		t.Errorf("affected rows should be 1, but %v", affected)
+	}
+	u, _ := User{}.Find(u2.Id)
+	if u.Age != 21 {
+		t.Errorf("age should be 21, but %v", u.Age)
+	}
+
+	affected, err = User{}.Where("age", "<", 25).Order("age", "ASC").Limit(1).DeleteAll()
+	assertError(t, err)
+	if affected != 1 {
+		t.Errorf("affected rows should be 1, but %v", affected)
+	}
+	users, _ := User{}.Order("id", "ASC").Query()
+	if len(users) != 2 {
+		t.Fatalf("record count should be 2, but %v", len(users))
+	}
+	if users[0].Id != u2.Id || users[1].Id != u3.Id {
+		t.Errorf("%v should be deleted", u1.Id)
+	}
+
+	_, err = User{}.Where("id", "IN", u2.Id, u3.Id).UpdateAll(UserParams{Name: "invalid"})
+	if !errors.Is(err, query.ErrInvalidCondition) {
+		t.Errorf("update all should fail with %v, but %v", query.ErrInvalidCondition, err)
+	}
+	_, err = User{}.Where("id", "IN", u2.Id, u3.Id).DeleteAll()
+	if !errors.Is(err, query.ErrInvalidCondition) {
+		t.Errorf("delete all should fail with %v, but %v", query.ErrInvalidCondition, err)
+	}
+	count = User{}.Where("name", "invalid").Or("name", "young").Count()
+	if count != 1 {
+		t.Errorf("record count should be 1, but %v", count)
+	}
+	count = User{}.Count()
+	if count != 2 {
+		t.Errorf("record count should be 2, but %v", count)
+	}
+}
+
+func TestIncrement(t *testing.T) {
+	defer User{}.DeleteAll()
+
+	u, _ := User{}.Create(UserParams{Name: "test", Age: 20})
+	stale, _ := User{}.Find(u.Id)
+
+	if ok, errs := u.Increment("age", 2); !ok {
+		t.Fatalf("increment should succeed, but %v", errs)
+	}
+	if ok, errs := stale.Increment("age", 3); !ok {
+		t.Fatalf("increment should succeed, but %v", errs)
+	}
+	if stale.Age != 25 {
+		t.Errorf("age should be 25, but %v", stale.Age)
+	}
+	if ok, errs := u.Decrement("age", 1); !ok {
+		t.Fatalf("decrement should succeed, but %v", errs)
+	}
+	if u.Age != 24 {
+		t.Errorf("age should be 24, but %v", u.Age)
+	}
+	expect, _ := User{}.Find(u.Id)
+	assertEqualStruct(t, u, expect)
+
+	if ok, _ := u.Increment("unknown", 1); ok {
+		t.Errorf("increment of unknown column should fail")
+	}
+
+	if ok, _ := u.Touch("name"); ok {
+		t.Errorf("touch of non timestamp column should fail")
+	}
+	expect, _ = User{}.Find(u.Id)
+	if expect.Name != "test" {
+		t.Errorf("name should not be touched, but %v", expect.Name)
+	}
+}
+
+func TestStmtCache(t *testing.T) {
+	UseStmtCache(2)
+	defer UseStmtCache(0)
+	defer User{}.DeleteAll()
+
+	u, _ := User{}.Create(UserParams{Name: "test", Age: 20})
+	for i := 0; i < 3; i++ {
+		user, err := User{}.Find(u.Id)
+		assertError(t, err)
+		assertEqualStruct(t, user, u)
+	}
+	count := User{}.Where("age", 20).Count()
+	if count != 1 {
+		t.Errorf("record count should be 1, but %v", count)
+	}
+	if stmtCache.Len() != 2 {
+		t.Errorf("cached statement count should be 2, but %v", stmtCache.Len())
+	}
+
+	err := Transaction(func(tx *ar.Tx) error {
+		_, errs := User{}.CreateTx(tx, UserParams{Name: "test2", Age: 30})
+		if errs != nil {
+			return errs
+		}
+		users, err := User{}.Tx(tx).Where("age", ">", 10).Query()
+		if len(users) != 2 {
+			t.Errorf("record count should be 2, but %v", len(users))
+		}
+		return err
+	})
+	assertError(t, err)
+	if stmtCache.Len() != 2 {
+		t.Errorf("cached statement count should be 2, but %v", stmtCache.Len())
+	}
+}
+
+func TestLock(t *testing.T) {
+	defer User{}.DeleteAll()
+
+	u, _ := User{}.Create(UserParams{Name: "test", Age: 20})
+	users, err := User{}.Where("age", 20).Lock(query.ForUpdateSkipLocked).Query()
+	assertError(t, err)
+	if len(users) != 1 {
+		t.Errorf("record count should be 1, but %v", len(users))
+	}
+
+	UseStrictLock(true)
+	defer UseStrictLock(false)
+	_, err = User{}.Where("age", 20).Lock(query.ForUpdate).Query()
+	if err != query.ErrLockNotSupported {
+		t.Errorf("error should be %v, but %v", query.ErrLockNotSupported, err)
+	}
+	locked := User{}.Where("age", 20).Lock(query.ForUpdate)
+	count := locked.Count()
+	if count != 1 {
+		t.Errorf("record count should ignore the lock, but %v", count)
+	}
+	if !locked.Exists() {
+		t.Errorf("exists should ignore the lock")
+	}
+	UseStrictLock(false)
+
+	invalid := User{}.Where("id", "IN", 1, 2)
+	if invalid.Exists() {
+		t.Errorf("exists should be false on error")
+	}
+
+	err = Transaction(func(tx *ar.Tx) error {
+		_, errs := u.IncrementTx(tx, "age", 10)
+		if errs != nil {
+			return errs
+		}
+		user := &User{Id: u.Id}
+		if err := user.ReloadTx(tx, query.ForUpdate); err != nil {
+			return err
+		}
+		assertEqualStruct(t, user, u)
+		return nil
+	})
+	assertError(t, err)
+
+	user := &User{Id: u.Id}
+	assertError(t, user.Reload())
+	if user.Age != 30 {
+		t.Errorf("age should be 30, but %v", user.Age)
+	}
+}
+
+func TestLeftJoins(t *testing.T) {
+	defer func() {
+		User{}.DeleteAll()
+		Post{}.Unscoped().DeleteAll()
+	}()
+
+	u, _ := User{}.Create(UserParams{Name: "test1"})
+	Post{}.Create(PostParams{UserId: u.Id, Name: "name"})
+	expect, _ := User{}.Create(UserParams{Name: "test2"})
+
+	users, err := User{}.LeftJoinsPosts().Where("posts.id", nil).Query()
+	assertError(t, err)
+	if len(users) != 1 {
+		t.Fatalf("record count should be 1, but %v", len(users))
+	}
+	assertEqualStruct(t, users[0], expect)
+
+	users, err = User{}.Joins("LEFT JOIN posts ON posts.user_id = users.id AND posts.name = ?", "name").Where("posts.id", "<>", nil).Query()
+	assertError(t, err)
+	if len(users) != 1 {
+		t.Fatalf("record count should be 1, but %v", len(users))
+	}
+	assertEqualStruct(t, users[0], u)
+}
+
 func TestExists(t *testing.T) {
 	defer User{}.DeleteAll()
 	exist := User{}.Exists()
@@ -419,6 +1269,262 @@ func TestAll(t *testing.T) {
 	}
 }
 
+func TestTransaction(t *testing.T) {
+	defer func() {
+		User{}.DeleteAll()
+		Post{}.Unscoped().DeleteAll()
+	}()
+
+	err := Transaction(func(tx *ar.Tx) error {
+		u, errs := User{}.CreateTx(tx, UserParams{Name: "test"})
+		if errs != nil {
+			return errs
+		}
+		if _, errs := u.BuildPost(PostParams{Name: "name"}).SaveTx(tx); errs != nil {
+			return errs
+		}
+		posts, err := u.PostsTx(tx)
+		if err != nil {
+			return err
+		}
+		if len(posts) != 1 {
+			t.Errorf("record count should be 1, but %v", len(posts))
+		}
+		return nil
+	})
+	assertError(t, err)
+
+	count := User{}.Count()
+	if count != 1 {
+		t.Errorf("record count should be 1, but %v", count)
+	}
+	count = Post{}.Count()
+	if count != 1 {
+		t.Errorf("record count should be 1, but %v", count)
+	}
+}
+
+func TestTransactionRollback(t *testing.T) {
+	defer func() {
+		User{}.DeleteAll()
+		Post{}.Unscoped().DeleteAll()
+	}()
+
+	expect := fmt.Errorf("rollback")
+	err := Transaction(func(tx *ar.Tx) error {
+		u, errs := User{}.CreateTx(tx, UserParams{Name: "test"})
+		if errs != nil {
+			return errs
+		}
+		if _, errs := u.BuildPost(PostParams{Name: "name"}).SaveTx(tx); errs != nil {
+			return errs
+		}
+		if _, errs := u.UpdateTx(tx, UserParams{Age: 20}); errs != nil {
+			return errs
+		}
+		if _, errs := u.UpdateColumnsTx(tx, UserParams{Name: "test2"}); errs != nil {
+			return errs
+		}
+		count := User{}.Tx(tx).Where("name", "test2").Where("age", 20).Count()
+		if count != 1 {
+			t.Errorf("record count should be 1, but %v", count)
+		}
+		return expect
+	})
+	if err != expect {
+		t.Errorf("error should be %v, but %v", expect, err)
+	}
+
+	count := User{}.Count()
+	if count != 0 {
+		t.Errorf("record count should be 0, but %v", count)
+	}
+	count = Post{}.Count()
+	if count != 0 {
+		t.Errorf("record count should be 0, but %v", count)
+	}
+}
+
+func TestNestedTransaction(t *testing.T) {
+	defer User{}.DeleteAll()
+
+	err := Transaction(func(tx *ar.Tx) error {
+		User{}.CreateTx(tx, UserParams{Name: "outer"})
+
+		err := TransactionTx(tx, func(tx *ar.Tx) error {
+			User{}.CreateTx(tx, UserParams{Name: "inner1"})
+			return fmt.Errorf("rollback")
+		})
+		if err == nil {
+			t.Errorf("error should be returned from nested transaction, but nil")
+		}
+
+		return tx.Transaction(func(tx *ar.Tx) error {
+			_, errs := User{}.CreateTx(tx, UserParams{Name: "inner2"})
+			if errs != nil {
+				return errs
+			}
+			return nil
+		})
+	})
+	assertError(t, err)
+
+	users, _ := User{}.Order("id", "ASC").Query()
+	expects := []string{"outer", "inner2"}
+	if len(users) != len(expects) {
+		t.Fatalf("record count should be %v, but %v", len(expects), len(users))
+	}
+	for i, u := range users {
+		if u.Name != expects[i] {
+			t.Errorf("column value should be %v, but %v", expects[i], u.Name)
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+	defer User{}.DeleteAll()
+
+	ctx := context.Background()
+	u, errs := User{}.CreateContext(ctx, UserParams{Name: "test"})
+	assertErrors(t, errs)
+
+	expect, err := User{}.FindContext(ctx, u.Id)
+	assertError(t, err)
+	assertEqualStruct(t, expect, u)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err = User{}.Where("name", "test").QueryContext(canceled)
+	if err == nil {
+		t.Errorf("error should be returned for canceled context, but nil")
+	}
+	u.Name = "test2"
+	if _, errs := u.SaveContext(canceled); errs == nil {
+		t.Errorf("errors should be returned for canceled context, but nil")
+	}
+	if err := TransactionContext(canceled, func(tx *ar.Tx) error { return nil }); err == nil {
+		t.Errorf("error should be returned for canceled context, but nil")
+	}
+}
+
+func TestCallbacks(t *testing.T) {
+	defer Comment{}.DeleteAll()
+
+	commentCallbacks = nil
+	c := &Comment{}
+	_, errs := c.Save()
+	assertErrors(t, errs)
+	assertCallbacks(t, []string{"beforeValidation", "afterValidation", "beforeSave", "beforeCreate", "afterCreate", "afterSave", "afterCommit"})
+
+	if c.Body != "(empty)" {
+		t.Errorf("column value should be changed by callback, but %v", c.Body)
+	}
+
+	commentCallbacks = nil
+	c.Body = "body"
+	_, errs = c.Save()
+	assertErrors(t, errs)
+	assertCallbacks(t, []string{"beforeValidation", "afterValidation", "beforeSave", "beforeUpdate", "afterUpdate", "afterSave", "afterCommit"})
+
+	commentCallbacks = nil
+	_, errs = c.Destroy()
+	assertErrors(t, errs)
+	assertCallbacks(t, []string{"beforeDestroy", "afterDestroy", "afterCommit"})
+}
+
+func TestTransactionContextCancel(t *testing.T) {
+	defer User{}.DeleteAll()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := TransactionContext(ctx, func(tx *ar.Tx) error {
+		cancel()
+		_, errs := User{}.CreateTx(tx, UserParams{Name: "test"})
+		if errs == nil {
+			t.Errorf("create in a canceled transaction should fail")
+			return nil
+		}
+		return errs
+	})
+	if err == nil {
+		t.Errorf("transaction should fail")
+	}
+
+	count := User{}.Count()
+	if count != 0 {
+		t.Errorf("record count should be 0, but %v", count)
+	}
+}
+
+func TestTransactionCallbacks(t *testing.T) {
+	defer Comment{}.DeleteAll()
+
+	commentCallbacks = nil
+	Transaction(func(tx *ar.Tx) error {
+		Comment{}.CreateTx(tx, CommentParams{Body: "body"})
+		if len(commentCallbacks) != 6 {
+			t.Errorf("afterCommit shouldn't be called before commit, but %v", commentCallbacks)
+		}
+		return nil
+	})
+	if commentCallbacks[len(commentCallbacks)-1] != "afterCommit" {
+		t.Errorf("afterCommit should be called after commit, but %v", commentCallbacks)
+	}
+
+	commentCallbacks = nil
+	Transaction(func(tx *ar.Tx) error {
+		Comment{}.CreateTx(tx, CommentParams{Body: "body"})
+		return fmt.Errorf("rollback")
+	})
+	if commentCallbacks[len(commentCallbacks)-1] != "afterRollback" {
+		t.Errorf("afterRollback should be called after rollback, but %v", commentCallbacks)
+	}
+
+	commentCallbacks = nil
+	Transaction(func(tx *ar.Tx) error {
+		tx.Transaction(func(tx *ar.Tx) error {
+			Comment{}.CreateTx(tx, CommentParams{Body: "body"})
+			return nil
+		})
+		if len(commentCallbacks) != 6 {
+			t.Errorf("afterCommit shouldn't be called before commit, but %v", commentCallbacks)
+		}
+		return nil
+	})
+	if commentCallbacks[len(commentCallbacks)-1] != "afterCommit" {
+		t.Errorf("afterCommit should be called after commit, but %v", commentCallbacks)
+	}
+}
+
+func TestCallbacksAbort(t *testing.T) {
+	defer Comment{}.DeleteAll()
+
+	c := &Comment{Body: "abort"}
+	_, errs := c.Save()
+	if errs == nil || len(errs.Messages["base"]) != 1 {
+		t.Errorf("errors count should be 1, but %v", errs)
+	}
+	if !c.IsNewRecord() {
+		t.Errorf("struct shouldn't be saved, but saved")
+	}
+
+	c, _ = Comment{}.Create(CommentParams{Body: "locked"})
+	_, errs = c.Destroy()
+	if errs == nil || len(errs.Messages["base"]) != 1 {
+		t.Errorf("errors count should be 1, but %v", errs)
+	}
+	exist := Comment{}.Where("id", c.Id).Exists()
+	if !exist {
+		t.Errorf("record shouldn't be deleted, but deleted")
+	}
+}
+
+func assertCallbacks(t *testing.T, expect []string) {
+	if !reflect.DeepEqual(expect, commentCallbacks) {
+		t.Errorf("callbacks should be called in %v, but %v", expect, commentCallbacks)
+	}
+}
+
 func assertEqualStruct(t *testing.T, expect, actual interface{}) {
 	if !reflect.DeepEqual(expect, actual) {
 		t.Errorf("struct should be equal to %v, but %v", expect, actual)
@@ -440,13 +1546,23 @@ func assertError(t *testing.T, err error) {
 func testDb() (*sql.DB, error) {
 	switch os.Getenv("DB") {
 	case "mysql":
-		return sql.Open("mysql", "travis@/argen_test")
+		return sql.Open("mysql", "travis@/argen_test?parseTime=true")
 	case "sqlite3", "":
 		return sql.Open("sqlite3", ":memory:")
 	}
 	return nil, nil
 }
 
+func testDialect() query.Dialect {
+	switch os.Getenv("DB") {
+	case "mysql":
+		return query.MySQL
+	case "sqlite3", "":
+		return query.SQLite
+	}
+	return nil
+}
+
 func testTables() []string {
 	switch os.Getenv("DB") {
 	case "mysql":
@@ -454,12 +1570,15 @@ func testTables() []string {
 			"drop table if exists users;",
 			"drop table if exists posts;",
 			"create table users (id INTEGER PRIMARY KEY AUTO_INCREMENT, name text, age integer);",
-			"create table posts (id INTEGER PRIMARY KEY AUTO_INCREMENT, user_id integer not null, name text);",
+			"create table posts (id INTEGER PRIMARY KEY AUTO_INCREMENT, user_id integer not null, name text, lock_version integer not null default 0, deleted_at datetime);",
+			"drop table if exists comments;",
+			"create table comments (id INTEGER PRIMARY KEY AUTO_INCREMENT, post_id integer, parent_id integer, body text, created_at datetime, updated_at datetime);",
 		}
 	case "sqlite3", "":
 		return []string{
 			"create table users (id integer PRIMARY KEY AUTOINCREMENT, name text, age integer);",
-			"create table posts (id integer PRIMARY KEY AUTOINCREMENT, user_id integer not null, name text);",
+			"create table posts (id integer PRIMARY KEY AUTOINCREMENT, user_id integer not null, name text, lock_version integer not null default 0, deleted_at datetime);",
+			"create table comments (id integer PRIMARY KEY AUTOINCREMENT, post_id integer, parent_id integer, body text, created_at datetime, updated_at datetime);",
 		}
 	}
 	return []string{}
@@ -1,13 +1,19 @@
 //go:generate go run ../cmd/argen/main.go
 package tests
 
-import "github.com/monochromegane/argen"
+import (
+	"time"
+
+	"github.com/monochromegane/argen"
+)
 
 //+AR
 type Post struct {
-	Id     int `db:"pk"`
-	UserId int `db:"fk"`
-	Name   string
+	Id          int `db:"pk"`
+	UserId      int `db:"fk"`
+	Name        string
+	LockVersion int `db:"lock_version"`
+	DeletedAt   *time.Time
 }
 
 func (p Post) belongsToUser() *ar.Association {
@@ -2,9 +2,11 @@
 package tests
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/monochromegane/argen"
+	"github.com/monochromegane/argen/query"
 )
 
 type PostRelation struct {
@@ -13,19 +15,39 @@ type PostRelation struct {
 }
 
 func (m *Post) newRelation() *PostRelation {
+	return m.newRelationTx(nil)
+}
+
+func (m *Post) newRelationTx(tx *ar.Tx) *PostRelation {
+	r := m.unscopedRelationTx(tx)
+
+	r.Relation.Scope("posts.deleted_at", nil)
+	return r
+}
+
+func (m *Post) unscopedRelationTx(tx *ar.Tx) *PostRelation {
 	r := &PostRelation{
 		m,
-		ar.NewRelation(db, logger).Table("posts"),
+		newRelation(tx).Table("posts").PrimaryKey("id"),
 	}
 	r.Select(
 		"id",
 		"user_id",
 		"name",
+		"lock_version",
+		"deleted_at",
 	)
-
 	return r
 }
 
+func (r *PostRelation) Clone() *PostRelation {
+	return &PostRelation{r.src, r.Relation.Clone()}
+}
+
+func (m Post) Tx(tx *ar.Tx) *PostRelation {
+	return m.newRelationTx(tx)
+}
+
 func (m Post) Select(columns ...string) *PostRelation {
 	return m.newRelation().Select(columns...)
 }
@@ -43,52 +65,143 @@ func (r *PostRelation) Select(columns ...string) *PostRelation {
 	return r
 }
 
+func (m Post) With(name string, query interface{}) *PostRelation {
+	return m.newRelation().With(name, query)
+}
+
+func (r *PostRelation) With(name string, query interface{}) *PostRelation {
+	r.Relation.With(name, query)
+	return r
+}
+
+func (m Post) WithRecursive(name string, query interface{}) *PostRelation {
+	return m.newRelation().WithRecursive(name, query)
+}
+
+func (r *PostRelation) WithRecursive(name string, query interface{}) *PostRelation {
+	r.Relation.WithRecursive(name, query)
+	return r
+}
+
 func (m Post) Find(id int) (*Post, error) {
 	return m.newRelation().Find(id)
 }
 
+func (m Post) FindContext(ctx context.Context, id int) (*Post, error) {
+	return m.newRelation().FindContext(ctx, id)
+}
+
 func (r *PostRelation) Find(id int) (*Post, error) {
-	return r.FindBy("id", id)
+	return r.FindContext(context.Background(), id)
+}
+
+func (r *PostRelation) FindContext(ctx context.Context, id int) (*Post, error) {
+	return r.FindByContext(ctx, "id", id)
 }
 
 func (m Post) FindBy(cond string, args ...interface{}) (*Post, error) {
 	return m.newRelation().FindBy(cond, args...)
 }
 
+func (m Post) FindByContext(ctx context.Context, cond string, args ...interface{}) (*Post, error) {
+	return m.newRelation().FindByContext(ctx, cond, args...)
+}
+
 func (r *PostRelation) FindBy(cond string, args ...interface{}) (*Post, error) {
-	return r.Where(cond, args...).Limit(1).QueryRow()
+	return r.FindByContext(context.Background(), cond, args...)
+}
+
+func (r *PostRelation) FindByContext(ctx context.Context, cond string, args ...interface{}) (*Post, error) {
+	return r.Clone().Where(cond, args...).Limit(1).QueryRowContext(ctx)
 }
 
 func (m Post) First() (*Post, error) {
 	return m.newRelation().First()
 }
 
+func (m Post) FirstContext(ctx context.Context) (*Post, error) {
+	return m.newRelation().FirstContext(ctx)
+}
+
 func (r *PostRelation) First() (*Post, error) {
-	return r.Order("id", "ASC").Limit(1).QueryRow()
+	return r.FirstContext(context.Background())
+}
+
+func (r *PostRelation) FirstContext(ctx context.Context) (*Post, error) {
+	return r.Clone().Order("id", "ASC").Limit(1).QueryRowContext(ctx)
 }
 
 func (m Post) Last() (*Post, error) {
 	return m.newRelation().Last()
 }
 
+func (m Post) LastContext(ctx context.Context) (*Post, error) {
+	return m.newRelation().LastContext(ctx)
+}
+
 func (r *PostRelation) Last() (*Post, error) {
-	return r.Order("id", "DESC").Limit(1).QueryRow()
+	return r.LastContext(context.Background())
+}
+
+func (r *PostRelation) LastContext(ctx context.Context) (*Post, error) {
+	return r.Clone().Order("id", "DESC").Limit(1).QueryRowContext(ctx)
 }
 
-func (m Post) Where(cond string, args ...interface{}) *PostRelation {
+func (m Post) Where(cond interface{}, args ...interface{}) *PostRelation {
 	return m.newRelation().Where(cond, args...)
 }
 
-func (r *PostRelation) Where(cond string, args ...interface{}) *PostRelation {
+func (r *PostRelation) Where(cond interface{}, args ...interface{}) *PostRelation {
 	r.Relation.Where(cond, args...)
 	return r
 }
 
-func (r *PostRelation) And(cond string, args ...interface{}) *PostRelation {
+func (r *PostRelation) And(cond interface{}, args ...interface{}) *PostRelation {
 	r.Relation.And(cond, args...)
 	return r
 }
 
+func (r *PostRelation) Or(cond interface{}, args ...interface{}) *PostRelation {
+	r.Relation.Or(cond, args...)
+	return r
+}
+
+func (m Post) Not(cond interface{}, args ...interface{}) *PostRelation {
+	return m.newRelation().Not(cond, args...)
+}
+
+func (r *PostRelation) Not(cond interface{}, args ...interface{}) *PostRelation {
+	r.Relation.Not(cond, args...)
+	return r
+}
+
+func (m Post) WhereExists(sub interface{}) *PostRelation {
+	return m.newRelation().WhereExists(sub)
+}
+
+func (r *PostRelation) WhereExists(sub interface{}) *PostRelation {
+	r.Relation.WhereExists(sub)
+	return r
+}
+
+func (m Post) WhereNotExists(sub interface{}) *PostRelation {
+	return m.newRelation().WhereNotExists(sub)
+}
+
+func (r *PostRelation) WhereNotExists(sub interface{}) *PostRelation {
+	r.Relation.WhereNotExists(sub)
+	return r
+}
+
+func (m Post) From(source interface{}, alias string) *PostRelation {
+	return m.newRelation().From(source, alias)
+}
+
+func (r *PostRelation) From(source interface{}, alias string) *PostRelation {
+	r.Relation.From(source, alias)
+	return r
+}
+
 func (m Post) Order(column, order string) *PostRelation {
 	return m.newRelation().Order(column, order)
 }
@@ -98,6 +211,51 @@ func (r *PostRelation) Order(column, order string) *PostRelation {
 	return r
 }
 
+func (m Post) Unscoped() *PostRelation {
+	return m.unscopedRelationTx(nil)
+}
+
+func (r *PostRelation) Unscoped() *PostRelation {
+	r.Relation.Unscoped()
+	return r
+}
+
+func (m Post) Unscope(clauses ...query.Clause) *PostRelation {
+	return m.newRelation().Unscope(clauses...)
+}
+
+func (r *PostRelation) Unscope(clauses ...query.Clause) *PostRelation {
+	r.Relation.Unscope(clauses...)
+	return r
+}
+
+func (m Post) Rewhere(cond string, args ...interface{}) *PostRelation {
+	return m.newRelation().Rewhere(cond, args...)
+}
+
+func (r *PostRelation) Rewhere(cond string, args ...interface{}) *PostRelation {
+	r.Relation.Rewhere(cond, args...)
+	return r
+}
+
+func (m Post) Reorder(column, order string) *PostRelation {
+	return m.newRelation().Reorder(column, order)
+}
+
+func (r *PostRelation) Reorder(column, order string) *PostRelation {
+	r.Relation.Reorder(column, order)
+	return r
+}
+
+func (m Post) ReverseOrder() *PostRelation {
+	return m.newRelation().ReverseOrder()
+}
+
+func (r *PostRelation) ReverseOrder() *PostRelation {
+	r.Relation.ReverseOrder()
+	return r
+}
+
 func (m Post) Limit(limit int) *PostRelation {
 	return m.newRelation().Limit(limit)
 }
@@ -130,9 +288,72 @@ func (r *PostRelation) Having(cond string, args ...interface{}) *PostRelation {
 	return r
 }
 
-func (m Post) IsValid() (bool, *ar.Errors) {
+func (m Post) Joins(sql string, args ...interface{}) *PostRelation {
+	return m.newRelation().Joins(sql, args...)
+}
+
+func (r *PostRelation) Joins(sql string, args ...interface{}) *PostRelation {
+	r.Relation.Joins(sql, args...)
+	return r
+}
+
+func (r *PostRelation) Union(query interface{}) *PostRelation {
+	r.Relation.Union(query)
+	return r
+}
+
+func (r *PostRelation) UnionAll(query interface{}) *PostRelation {
+	r.Relation.UnionAll(query)
+	return r
+}
+
+func (r *PostRelation) Intersect(query interface{}) *PostRelation {
+	r.Relation.Intersect(query)
+	return r
+}
+
+func (r *PostRelation) Except(query interface{}) *PostRelation {
+	r.Relation.Except(query)
+	return r
+}
+
+func (m Post) Lock(mode query.LockMode) *PostRelation {
+	return m.newRelation().Lock(mode)
+}
+
+func (r *PostRelation) Lock(mode query.LockMode) *PostRelation {
+	r.Relation.Lock(mode)
+	return r
+}
+
+func (m *Post) Reload(lock ...query.LockMode) error {
+	return m.ReloadContext(context.Background(), lock...)
+}
+
+func (m *Post) ReloadTx(tx *ar.Tx, lock ...query.LockMode) error {
+	return m.reload(tx.Context(), tx, lock)
+}
+
+func (m *Post) ReloadContext(ctx context.Context, lock ...query.LockMode) error {
+	return m.reload(ctx, nil, lock)
+}
+
+func (m *Post) reload(ctx context.Context, tx *ar.Tx, lock []query.LockMode) error {
+	r := m.newRelationTx(tx).Where("id", m.Id)
+	if len(lock) > 0 {
+		r.Lock(lock[0])
+	}
+	if err := r.Relation.QueryRowContext(ctx, m.fieldPtrsByName(r.Relation.GetColumns())...); err != nil {
+		return err
+	}
+	m.takeSnapshot()
+	return nil
+}
+
+func (m *Post) IsValid() (bool, *ar.Errors) {
 	result := true
 	errors := &ar.Errors{}
+
 	var on ar.On
 	if m.IsNewRecord() {
 		on = ar.OnCreate()
@@ -158,10 +379,101 @@ func (m Post) IsValid() (bool, *ar.Errors) {
 	if len(errors.Messages) > 0 {
 		result = false
 	}
+
 	return result, errors
 }
 
+func (m *Post) takeSnapshot(columns ...string) {
+}
+
+func (m *Post) changedColumns() []string {
+	columns := []string{}
+	for _, c := range m.columnNames() {
+		if c == "id" {
+			continue
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+func (m *Post) setCreateTimestamps() {
+}
+
+func (m *Post) setUpdateTimestamps() {
+}
+
+func (m Post) WithDeleted() *PostRelation {
+	return m.newRelation().WithDeleted()
+}
+
+func (r *PostRelation) WithDeleted() *PostRelation {
+	r.Relation.UnscopeWhere("posts.deleted_at")
+	return r
+}
+
+func (m Post) OnlyDeleted() *PostRelation {
+	return m.newRelation().OnlyDeleted()
+}
+
+func (r *PostRelation) OnlyDeleted() *PostRelation {
+	r.WithDeleted().Relation.Where("posts.deleted_at", "IS NOT", nil)
+	return r
+}
+
+func (m *Post) IsDeleted() bool {
+	return m.DeletedAt != nil
+}
+
+func (m *Post) Restore() (bool, *ar.Errors) {
+	return m.restore(context.Background(), nil)
+}
+
+func (m *Post) RestoreTx(tx *ar.Tx) (bool, *ar.Errors) {
+	return m.restore(tx.Context(), tx)
+}
+
+func (m *Post) RestoreContext(ctx context.Context) (bool, *ar.Errors) {
+	return m.restore(ctx, nil)
+}
+
+func (m *Post) restore(ctx context.Context, tx *ar.Tx) (bool, *ar.Errors) {
+	errs := &ar.Errors{}
+	upd := newUpdate(tx).Table("posts").Params(map[string]interface{}{"deleted_at": nil}).Where("id", m.Id)
+	if _, err := upd.ExecContext(ctx); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}
+	m.DeletedAt = nil
+	m.takeSnapshot("deleted_at")
+	return true, nil
+}
+
+func (m *Post) ReallyDestroy() (bool, *ar.Errors) {
+	return m.delete(context.Background(), nil, true)
+}
+
+func (m *Post) ReallyDestroyTx(tx *ar.Tx) (bool, *ar.Errors) {
+	return m.delete(tx.Context(), tx, true)
+}
+
+func (m *Post) ReallyDestroyContext(ctx context.Context) (bool, *ar.Errors) {
+	return m.delete(ctx, nil, true)
+}
+
 func (m *Post) User() (*User, error) {
+	return m.belongsToUserRelation(nil).QueryRow()
+}
+
+func (m *Post) UserTx(tx *ar.Tx) (*User, error) {
+	return m.belongsToUserRelation(tx).QueryRow()
+}
+
+func (m *Post) UserContext(ctx context.Context) (*User, error) {
+	return m.belongsToUserRelation(nil).QueryRowContext(ctx)
+}
+
+func (m *Post) belongsToUserRelation(tx *ar.Tx) *UserRelation {
 	asc := m.belongsToUser()
 	pk := "id"
 	fk := "user_id"
@@ -171,7 +483,7 @@ func (m *Post) User() (*User, error) {
 	if asc != nil && asc.ForeignKey != "" {
 		fk = asc.ForeignKey
 	}
-	return User{}.Where(pk, m.fieldValueByName(fk)).QueryRow()
+	return User{}.Tx(tx).Where(pk, m.fieldValueByName(fk))
 }
 
 func (m Post) JoinsUser() *PostRelation {
@@ -179,6 +491,20 @@ func (m Post) JoinsUser() *PostRelation {
 }
 
 func (r *PostRelation) JoinsUser() *PostRelation {
+	r.Relation.InnerJoin("users", r.belongsToUserOn())
+	return r
+}
+
+func (m Post) LeftJoinsUser() *PostRelation {
+	return m.newRelation().LeftJoinsUser()
+}
+
+func (r *PostRelation) LeftJoinsUser() *PostRelation {
+	r.Relation.LeftJoin("users", r.belongsToUserOn())
+	return r
+}
+
+func (r *PostRelation) belongsToUserOn() string {
 	asc := r.src.belongsToUser()
 	pk := "id"
 	fk := "user_id"
@@ -188,26 +514,216 @@ func (r *PostRelation) JoinsUser() *PostRelation {
 	if asc != nil && asc.ForeignKey != "" {
 		fk = asc.ForeignKey
 	}
-	r.Relation.InnerJoin("users", fmt.Sprintf("users.%s = posts.%s", pk, fk))
-	return r
+	return fmt.Sprintf("users.%s = posts.%s", pk, fk)
 }
 
 type PostParams Post
 
 func (m Post) Build(p PostParams) *Post {
 	return &Post{
-		Id:     p.Id,
-		UserId: p.UserId,
-		Name:   p.Name,
+		Id:          p.Id,
+		UserId:      p.UserId,
+		Name:        p.Name,
+		LockVersion: p.LockVersion,
+		DeletedAt:   p.DeletedAt,
 	}
 }
 
 func (m Post) Create(p PostParams) (*Post, *ar.Errors) {
+	return m.create(context.Background(), nil, p)
+}
+
+func (m Post) CreateTx(tx *ar.Tx, p PostParams) (*Post, *ar.Errors) {
+	return m.create(tx.Context(), tx, p)
+}
+
+func (m Post) CreateContext(ctx context.Context, p PostParams) (*Post, *ar.Errors) {
+	return m.create(ctx, nil, p)
+}
+
+func (m Post) create(ctx context.Context, tx *ar.Tx, p PostParams) (*Post, *ar.Errors) {
 	n := m.Build(p)
-	_, errs := n.Save()
+	_, errs := n.save(ctx, tx)
 	return n, errs
 }
 
+func (m Post) Upsert(p PostParams, conflictColumns ...string) (*Post, *ar.Errors) {
+	return m.upsert(context.Background(), nil, p, conflictColumns...)
+}
+
+func (m Post) UpsertTx(tx *ar.Tx, p PostParams, conflictColumns ...string) (*Post, *ar.Errors) {
+	return m.upsert(tx.Context(), tx, p, conflictColumns...)
+}
+
+func (m Post) UpsertContext(ctx context.Context, p PostParams, conflictColumns ...string) (*Post, *ar.Errors) {
+	return m.upsert(ctx, nil, p, conflictColumns...)
+}
+
+func (m Post) upsert(ctx context.Context, tx *ar.Tx, p PostParams, conflictColumns ...string) (*Post, *ar.Errors) {
+	n := m.Build(p)
+	if ok, errs := n.IsValid(); !ok {
+		return nil, errs
+	}
+	errs := &ar.Errors{}
+
+	n.setCreateTimestamps()
+	params := map[string]interface{}{
+		"user_id":      n.UserId,
+		"name":         n.Name,
+		"lock_version": n.LockVersion,
+		"deleted_at":   n.DeletedAt,
+	}
+	rel := newRelation(tx).Table("posts").Columns(n.columnNames()...)
+	for _, c := range conflictColumns {
+		if !n.isColumnName(c) {
+			errs.Add(c, "is not a column")
+			return nil, errs
+		}
+		v := n.fieldValueByName(c)
+		if _, ok := params[c]; !ok {
+			if ar.IsZero(v) {
+				errs.Add(c, "can't be blank")
+				return nil, errs
+			}
+			params[c] = v
+		}
+		rel.Where(c, v)
+	}
+	ins := newInsert(tx).Table("posts").Params(params).OnConflict(conflictColumns...)
+	if columns := m.upsertColumns(conflictColumns); len(columns) > 0 {
+		ins.DoUpdate(columns...)
+	} else if len(conflictColumns) > 0 {
+		ins.DoUpdate(conflictColumns...)
+	} else {
+		ins.DoNothing()
+	}
+	if err := ins.ExecReturningContext(ctx, "id", &n.Id); err != nil {
+		errs.AddError("base", err)
+		return nil, errs
+	}
+	if len(conflictColumns) == 0 {
+		rel.Where("id", n.Id)
+	}
+	if err := rel.QueryRowContext(ctx, n.fieldPtrsByName(n.columnNames())...); err != nil {
+		errs.AddError("base", err)
+		return nil, errs
+	}
+	n.takeSnapshot()
+	return n, nil
+}
+
+func (m Post) upsertColumns(conflictColumns []string) []string {
+	target := map[string]bool{}
+	for _, c := range conflictColumns {
+		target[c] = true
+	}
+	columns := []string{}
+	for _, c := range m.columnNames() {
+		if c == "id" || c == "lock_version" || target[c] {
+			continue
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+func (m Post) UpsertAll(ps []PostParams, conflictColumns ...string) ([]*Post, *ar.Errors) {
+	return m.upsertAll(context.Background(), nil, ps, conflictColumns...)
+}
+
+func (m Post) UpsertAllTx(tx *ar.Tx, ps []PostParams, conflictColumns ...string) ([]*Post, *ar.Errors) {
+	return m.upsertAll(tx.Context(), tx, ps, conflictColumns...)
+}
+
+func (m Post) UpsertAllContext(ctx context.Context, ps []PostParams, conflictColumns ...string) ([]*Post, *ar.Errors) {
+	return m.upsertAll(ctx, nil, ps, conflictColumns...)
+}
+
+func (m Post) upsertAll(ctx context.Context, tx *ar.Tx, ps []PostParams, conflictColumns ...string) ([]*Post, *ar.Errors) {
+	var ns []*Post
+	var errs *ar.Errors
+	fn := func(tx *ar.Tx) error {
+		for _, p := range ps {
+			n, e := m.upsert(ctx, tx, p, conflictColumns...)
+			if e != nil {
+				errs = e
+				return e
+			}
+			ns = append(ns, n)
+		}
+		return nil
+	}
+
+	var err error
+	if tx != nil {
+		err = tx.Transaction(fn)
+	} else {
+		err = TransactionContext(ctx, fn)
+	}
+	if err != nil {
+		if errs == nil {
+			errs = &ar.Errors{}
+			errs.AddError("base", err)
+		}
+		return nil, errs
+	}
+	return ns, nil
+}
+
+func (m Post) InsertAll(ps []PostParams, validate ...bool) (int64, *ar.Errors) {
+	return m.insertAll(context.Background(), nil, ps, validate...)
+}
+
+func (m Post) InsertAllTx(tx *ar.Tx, ps []PostParams, validate ...bool) (int64, *ar.Errors) {
+	return m.insertAll(tx.Context(), tx, ps, validate...)
+}
+
+func (m Post) InsertAllContext(ctx context.Context, ps []PostParams, validate ...bool) (int64, *ar.Errors) {
+	return m.insertAll(ctx, nil, ps, validate...)
+}
+
+func (m Post) insertAll(ctx context.Context, tx *ar.Tx, ps []PostParams, validate ...bool) (int64, *ar.Errors) {
+	if len(ps) == 0 {
+		return 0, nil
+	}
+	rows := make([]map[string]interface{}, len(ps))
+	for i, p := range ps {
+		n := m.Build(p)
+		n.setCreateTimestamps()
+		if len(validate) == 0 || len(validate) > 0 && validate[0] {
+			if ok, errs := n.IsValid(); !ok {
+				return 0, errs
+			}
+		}
+		rows[i] = map[string]interface{}{
+			"user_id":      n.UserId,
+			"name":         n.Name,
+			"lock_version": n.LockVersion,
+			"deleted_at":   n.DeletedAt,
+		}
+	}
+
+	var affected int64
+	fn := func(tx *ar.Tx) error {
+		var err error
+		affected, err = newInsert(tx).Table("posts").Rows(rows...).ExecAllContext(ctx)
+		return err
+	}
+
+	var err error
+	if tx != nil {
+		err = tx.Transaction(fn)
+	} else {
+		err = TransactionContext(ctx, fn)
+	}
+	if err != nil {
+		errs := &ar.Errors{}
+		errs.AddError("base", err)
+		return 0, errs
+	}
+	return affected, nil
+}
+
 func (m *Post) IsNewRecord() bool {
 	return ar.IsZero(m.Id)
 }
@@ -217,43 +733,83 @@ func (m *Post) IsPersistent() bool {
 }
 
 func (m *Post) Save(validate ...bool) (bool, *ar.Errors) {
+	return m.save(context.Background(), nil, validate...)
+}
+
+func (m *Post) SaveTx(tx *ar.Tx, validate ...bool) (bool, *ar.Errors) {
+	return m.save(tx.Context(), tx, validate...)
+}
+
+func (m *Post) SaveContext(ctx context.Context, validate ...bool) (bool, *ar.Errors) {
+	return m.save(ctx, nil, validate...)
+}
+
+func (m *Post) save(ctx context.Context, tx *ar.Tx, validate ...bool) (bool, *ar.Errors) {
 	if len(validate) == 0 || len(validate) > 0 && validate[0] {
 		if ok, errs := m.IsValid(); !ok {
 			return false, errs
 		}
 	}
 	errs := &ar.Errors{}
+
 	if m.IsNewRecord() {
-		ins := ar.NewInsert(db, logger).Table("posts").Params(map[string]interface{}{
-			"user_id": m.UserId,
-			"name":    m.Name,
+
+		m.setCreateTimestamps()
+		ins := newInsert(tx).Table("posts").Params(map[string]interface{}{
+			"user_id":      m.UserId,
+			"name":         m.Name,
+			"lock_version": m.LockVersion,
+			"deleted_at":   m.DeletedAt,
 		})
 
-		if result, err := ins.Exec(); err != nil {
+		if err := ins.ExecReturningContext(ctx, "id", &m.Id); err != nil {
 			errs.AddError("base", err)
 			return false, errs
-		} else {
-			if lastId, err := result.LastInsertId(); err == nil {
-				m.Id = int(lastId)
-			}
 		}
-		return true, nil
+		m.takeSnapshot()
+
 	} else {
-		upd := ar.NewUpdate(db, logger).Table("posts").Params(map[string]interface{}{
-			"id":      m.Id,
-			"user_id": m.UserId,
-			"name":    m.Name,
-		}).Where("id", m.Id)
 
-		if _, err := upd.Exec(); err != nil {
-			errs.AddError("base", err)
-			return false, errs
+		if columns := m.changedColumns(); len(columns) > 0 {
+			params := map[string]interface{}{}
+			for _, c := range columns {
+				params[c] = m.fieldValueByName(c)
+			}
+
+			params["lock_version"] = m.LockVersion + 1
+			upd := newUpdate(tx).Table("posts").Params(params).Where("id", m.Id).Where("lock_version", m.LockVersion)
+
+			result, err := upd.ExecContext(ctx)
+			if err != nil {
+				errs.AddError("base", err)
+				return false, errs
+			}
+			if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+				errs.AddError("base", ar.ErrStaleObject)
+				return false, errs
+			}
+			m.LockVersion++
+			m.takeSnapshot()
 		}
-		return true, nil
+
 	}
+
+	return true, nil
 }
 
 func (m *Post) Update(p PostParams) (bool, *ar.Errors) {
+	return m.update(context.Background(), nil, p)
+}
+
+func (m *Post) UpdateTx(tx *ar.Tx, p PostParams) (bool, *ar.Errors) {
+	return m.update(tx.Context(), tx, p)
+}
+
+func (m *Post) UpdateContext(ctx context.Context, p PostParams) (bool, *ar.Errors) {
+	return m.update(ctx, nil, p)
+}
+
+func (m *Post) update(ctx context.Context, tx *ar.Tx, p PostParams, validate ...bool) (bool, *ar.Errors) {
 
 	if !ar.IsZero(p.Id) {
 		m.Id = p.Id
@@ -264,47 +820,226 @@ func (m *Post) Update(p PostParams) (bool, *ar.Errors) {
 	if !ar.IsZero(p.Name) {
 		m.Name = p.Name
 	}
-	return m.Save()
+	if !ar.IsZero(p.LockVersion) {
+		m.LockVersion = p.LockVersion
+	}
+	if !ar.IsZero(p.DeletedAt) {
+		m.DeletedAt = p.DeletedAt
+	}
+	return m.save(ctx, tx, validate...)
 }
 
 func (m *Post) UpdateColumns(p PostParams) (bool, *ar.Errors) {
+	return m.update(context.Background(), nil, p, false)
+}
 
-	if !ar.IsZero(p.Id) {
-		m.Id = p.Id
+func (m *Post) UpdateColumnsTx(tx *ar.Tx, p PostParams) (bool, *ar.Errors) {
+	return m.update(tx.Context(), tx, p, false)
+}
+
+func (m *Post) UpdateColumnsContext(ctx context.Context, p PostParams) (bool, *ar.Errors) {
+	return m.update(ctx, nil, p, false)
+}
+
+func (m Post) UpdateAll(params interface{}) (int64, error) {
+	return m.newRelation().UpdateAll(params)
+}
+
+func (m Post) UpdateAllContext(ctx context.Context, params interface{}) (int64, error) {
+	return m.newRelation().UpdateAllContext(ctx, params)
+}
+
+func (r *PostRelation) UpdateAll(params interface{}) (int64, error) {
+	return r.UpdateAllContext(context.Background(), params)
+}
+
+func (r *PostRelation) UpdateAllContext(ctx context.Context, params interface{}) (int64, error) {
+	var p map[string]interface{}
+	switch params := params.(type) {
+	case map[string]interface{}:
+		p = params
+	case PostParams:
+		p = map[string]interface{}{}
+		if !ar.IsZero(params.Id) {
+			p["id"] = params.Id
+		}
+		if !ar.IsZero(params.UserId) {
+			p["user_id"] = params.UserId
+		}
+		if !ar.IsZero(params.Name) {
+			p["name"] = params.Name
+		}
+		if !ar.IsZero(params.LockVersion) {
+			p["lock_version"] = params.LockVersion
+		}
+		if !ar.IsZero(params.DeletedAt) {
+			p["deleted_at"] = params.DeletedAt
+		}
+	default:
+		return 0, fmt.Errorf("unsupported params type %T", params)
 	}
-	if !ar.IsZero(p.UserId) {
-		m.UserId = p.UserId
+	if len(p) == 0 {
+		return 0, nil
 	}
-	if !ar.IsZero(p.Name) {
-		m.Name = p.Name
+
+	return r.Relation.UpdateAllContext(ctx, p)
+}
+
+func (m *Post) Increment(column string, by int) (bool, *ar.Errors) {
+	return m.IncrementContext(context.Background(), column, by)
+}
+
+func (m *Post) IncrementTx(tx *ar.Tx, column string, by int) (bool, *ar.Errors) {
+	return m.updateExpressions(tx.Context(), tx, map[string]interface{}{
+		column: query.Increment(column, by),
+	})
+}
+
+func (m *Post) IncrementContext(ctx context.Context, column string, by int) (bool, *ar.Errors) {
+	return m.updateExpressions(ctx, nil, map[string]interface{}{
+		column: query.Increment(column, by),
+	})
+}
+
+func (m *Post) Decrement(column string, by int) (bool, *ar.Errors) {
+	return m.IncrementContext(context.Background(), column, -by)
+}
+
+func (m *Post) DecrementTx(tx *ar.Tx, column string, by int) (bool, *ar.Errors) {
+	return m.IncrementTx(tx, column, -by)
+}
+
+func (m *Post) DecrementContext(ctx context.Context, column string, by int) (bool, *ar.Errors) {
+	return m.IncrementContext(ctx, column, -by)
+}
+
+func (m *Post) Touch(columns ...string) (bool, *ar.Errors) {
+	return m.TouchContext(context.Background(), columns...)
+}
+
+func (m *Post) TouchTx(tx *ar.Tx, columns ...string) (bool, *ar.Errors) {
+	return m.touch(tx.Context(), tx, columns)
+}
+
+func (m *Post) TouchContext(ctx context.Context, columns ...string) (bool, *ar.Errors) {
+	return m.touch(ctx, nil, columns)
+}
+
+func (m *Post) touch(ctx context.Context, tx *ar.Tx, columns []string) (bool, *ar.Errors) {
+	for _, c := range columns {
+		if !m.isTimeColumn(c) {
+			errs := &ar.Errors{}
+			errs.Add(c, "is not a timestamp")
+			return false, errs
+		}
 	}
-	return m.Save(false)
+	return m.updateExpressions(ctx, tx, m.touchParams(columns))
 }
 
-func (m *Post) Destroy() (bool, *ar.Errors) {
-	return m.Delete()
+func (m *Post) isTimeColumn(name string) bool {
+	switch name {
+	case "deleted_at":
+		return true
+	}
+	return false
 }
 
-func (m *Post) Delete() (bool, *ar.Errors) {
+func (m *Post) touchParams(columns []string) map[string]interface{} {
+	now := ar.Now()
+	params := map[string]interface{}{}
+	for _, c := range columns {
+		params[c] = now
+	}
+	return params
+}
+
+func (m *Post) updateExpressions(ctx context.Context, tx *ar.Tx, params map[string]interface{}) (bool, *ar.Errors) {
 	errs := &ar.Errors{}
-	if _, err := ar.NewDelete(db, logger).Table("posts").Where("id", m.Id).Exec(); err != nil {
+	if len(params) == 0 {
+		return true, nil
+	}
+	columns := []string{}
+	for c := range params {
+		if !m.isColumnName(c) {
+			errs.Add(c, "is not a column")
+			return false, errs
+		}
+		columns = append(columns, c)
+	}
+
+	upd := newUpdate(tx).Table("posts").Params(params).Where("id", m.Id)
+	if _, err := upd.ExecContext(ctx); err != nil {
 		errs.AddError("base", err)
 		return false, errs
 	}
+
+	rel := newRelation(tx).Table("posts").Columns(columns...).Where("id", m.Id)
+	if err := rel.QueryRowContext(ctx, m.fieldPtrsByName(columns)...); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}
+	m.takeSnapshot(columns...)
 	return true, nil
 }
 
-func (m Post) DeleteAll() (bool, *ar.Errors) {
+func (m *Post) Destroy() (bool, *ar.Errors) {
+	return m.delete(context.Background(), nil, false)
+}
+
+func (m *Post) DestroyTx(tx *ar.Tx) (bool, *ar.Errors) {
+	return m.delete(tx.Context(), tx, false)
+}
+
+func (m *Post) DestroyContext(ctx context.Context) (bool, *ar.Errors) {
+	return m.delete(ctx, nil, false)
+}
+
+func (m *Post) Delete() (bool, *ar.Errors) {
+	return m.delete(context.Background(), nil, false)
+}
+
+func (m *Post) DeleteTx(tx *ar.Tx) (bool, *ar.Errors) {
+	return m.delete(tx.Context(), tx, false)
+}
+
+func (m *Post) DeleteContext(ctx context.Context) (bool, *ar.Errors) {
+	return m.delete(ctx, nil, false)
+}
+
+func (m *Post) delete(ctx context.Context, tx *ar.Tx, really bool) (bool, *ar.Errors) {
 	errs := &ar.Errors{}
-	if _, err := ar.NewDelete(db, logger).Table("posts").Exec(); err != nil {
+
+	if !really {
+		now := ar.Now()
+		upd := newUpdate(tx).Table("posts").Params(map[string]interface{}{"deleted_at": now}).Where("id", m.Id)
+		if _, err := upd.ExecContext(ctx); err != nil {
+			errs.AddError("base", err)
+			return false, errs
+		}
+		m.DeletedAt = &now
+		m.takeSnapshot("deleted_at")
+	} else if _, err := newDelete(tx).Table("posts").Where("id", m.Id).ExecContext(ctx); err != nil {
 		errs.AddError("base", err)
 		return false, errs
 	}
+
 	return true, nil
 }
 
+func (m Post) DeleteAll() (int64, error) {
+	return m.newRelation().DeleteAll()
+}
+
+func (m Post) DeleteAllContext(ctx context.Context) (int64, error) {
+	return m.newRelation().DeleteAllContext(ctx)
+}
+
 func (r *PostRelation) Query() ([]*Post, error) {
-	rows, err := r.Relation.Query()
+	return r.QueryContext(context.Background())
+}
+
+func (r *PostRelation) QueryContext(ctx context.Context) ([]*Post, error) {
+	rows, err := r.Relation.QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -317,17 +1052,23 @@ func (r *PostRelation) Query() ([]*Post, error) {
 		if err != nil {
 			return nil, err
 		}
+		row.takeSnapshot()
 		results = append(results, row)
 	}
 	return results, nil
 }
 
 func (r *PostRelation) QueryRow() (*Post, error) {
+	return r.QueryRowContext(context.Background())
+}
+
+func (r *PostRelation) QueryRowContext(ctx context.Context) (*Post, error) {
 	row := &Post{}
-	err := r.Relation.QueryRow(row.fieldPtrsByName(r.Relation.GetColumns())...)
+	err := r.Relation.QueryRowContext(ctx, row.fieldPtrsByName(r.Relation.GetColumns())...)
 	if err != nil {
 		return nil, err
 	}
+	row.takeSnapshot()
 	return row, nil
 }
 
@@ -335,10 +1076,18 @@ func (m Post) Exists() bool {
 	return m.newRelation().Exists()
 }
 
+func (m Post) ExistsContext(ctx context.Context) bool {
+	return m.newRelation().ExistsContext(ctx)
+}
+
 func (m Post) Count(column ...string) int {
 	return m.newRelation().Count(column...)
 }
 
+func (m Post) CountContext(ctx context.Context, column ...string) int {
+	return m.newRelation().CountContext(ctx, column...)
+}
+
 func (m Post) All() *PostRelation {
 	return m.newRelation().All()
 }
@@ -355,6 +1104,10 @@ func (m *Post) fieldValueByName(name string) interface{} {
 		return m.UserId
 	case "name", "posts.name":
 		return m.Name
+	case "lock_version", "posts.lock_version":
+		return m.LockVersion
+	case "deleted_at", "posts.deleted_at":
+		return m.DeletedAt
 	default:
 		return ""
 	}
@@ -368,6 +1121,10 @@ func (m *Post) fieldPtrByName(name string) interface{} {
 		return &m.UserId
 	case "name", "posts.name":
 		return &m.Name
+	case "lock_version", "posts.lock_version":
+		return &m.LockVersion
+	case "deleted_at", "posts.deleted_at":
+		return &m.DeletedAt
 	default:
 		return nil
 	}
@@ -396,5 +1153,7 @@ func (m *Post) columnNames() []string {
 		"id",
 		"user_id",
 		"name",
+		"lock_version",
+		"deleted_at",
 	}
 }
@@ -5,6 +5,7 @@ import "github.com/monochromegane/argen"
 
 //+AR
 type User struct {
+	ar.Snapshot
 	Id   int `db:"pk"`
 	Name string
 	Age  int
@@ -2,9 +2,11 @@
 package tests
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/monochromegane/argen"
+	"github.com/monochromegane/argen/query"
 )
 
 type UserRelation struct {
@@ -13,19 +15,36 @@ type UserRelation struct {
 }
 
 func (m *User) newRelation() *UserRelation {
+	return m.newRelationTx(nil)
+}
+
+func (m *User) newRelationTx(tx *ar.Tx) *UserRelation {
+	r := m.unscopedRelationTx(tx)
+
+	return r
+}
+
+func (m *User) unscopedRelationTx(tx *ar.Tx) *UserRelation {
 	r := &UserRelation{
 		m,
-		ar.NewRelation(db, logger).Table("users"),
+		newRelation(tx).Table("users").PrimaryKey("id"),
 	}
 	r.Select(
 		"id",
 		"name",
 		"age",
 	)
-
 	return r
 }
 
+func (r *UserRelation) Clone() *UserRelation {
+	return &UserRelation{r.src, r.Relation.Clone()}
+}
+
+func (m User) Tx(tx *ar.Tx) *UserRelation {
+	return m.newRelationTx(tx)
+}
+
 func (m User) Select(columns ...string) *UserRelation {
 	return m.newRelation().Select(columns...)
 }
@@ -43,52 +62,143 @@ func (r *UserRelation) Select(columns ...string) *UserRelation {
 	return r
 }
 
+func (m User) With(name string, query interface{}) *UserRelation {
+	return m.newRelation().With(name, query)
+}
+
+func (r *UserRelation) With(name string, query interface{}) *UserRelation {
+	r.Relation.With(name, query)
+	return r
+}
+
+func (m User) WithRecursive(name string, query interface{}) *UserRelation {
+	return m.newRelation().WithRecursive(name, query)
+}
+
+func (r *UserRelation) WithRecursive(name string, query interface{}) *UserRelation {
+	r.Relation.WithRecursive(name, query)
+	return r
+}
+
 func (m User) Find(id int) (*User, error) {
 	return m.newRelation().Find(id)
 }
 
+func (m User) FindContext(ctx context.Context, id int) (*User, error) {
+	return m.newRelation().FindContext(ctx, id)
+}
+
 func (r *UserRelation) Find(id int) (*User, error) {
-	return r.FindBy("id", id)
+	return r.FindContext(context.Background(), id)
+}
+
+func (r *UserRelation) FindContext(ctx context.Context, id int) (*User, error) {
+	return r.FindByContext(ctx, "id", id)
 }
 
 func (m User) FindBy(cond string, args ...interface{}) (*User, error) {
 	return m.newRelation().FindBy(cond, args...)
 }
 
+func (m User) FindByContext(ctx context.Context, cond string, args ...interface{}) (*User, error) {
+	return m.newRelation().FindByContext(ctx, cond, args...)
+}
+
 func (r *UserRelation) FindBy(cond string, args ...interface{}) (*User, error) {
-	return r.Where(cond, args...).Limit(1).QueryRow()
+	return r.FindByContext(context.Background(), cond, args...)
+}
+
+func (r *UserRelation) FindByContext(ctx context.Context, cond string, args ...interface{}) (*User, error) {
+	return r.Clone().Where(cond, args...).Limit(1).QueryRowContext(ctx)
 }
 
 func (m User) First() (*User, error) {
 	return m.newRelation().First()
 }
 
+func (m User) FirstContext(ctx context.Context) (*User, error) {
+	return m.newRelation().FirstContext(ctx)
+}
+
 func (r *UserRelation) First() (*User, error) {
-	return r.Order("id", "ASC").Limit(1).QueryRow()
+	return r.FirstContext(context.Background())
+}
+
+func (r *UserRelation) FirstContext(ctx context.Context) (*User, error) {
+	return r.Clone().Order("id", "ASC").Limit(1).QueryRowContext(ctx)
 }
 
 func (m User) Last() (*User, error) {
 	return m.newRelation().Last()
 }
 
+func (m User) LastContext(ctx context.Context) (*User, error) {
+	return m.newRelation().LastContext(ctx)
+}
+
 func (r *UserRelation) Last() (*User, error) {
-	return r.Order("id", "DESC").Limit(1).QueryRow()
+	return r.LastContext(context.Background())
+}
+
+func (r *UserRelation) LastContext(ctx context.Context) (*User, error) {
+	return r.Clone().Order("id", "DESC").Limit(1).QueryRowContext(ctx)
 }
 
-func (m User) Where(cond string, args ...interface{}) *UserRelation {
+func (m User) Where(cond interface{}, args ...interface{}) *UserRelation {
 	return m.newRelation().Where(cond, args...)
 }
 
-func (r *UserRelation) Where(cond string, args ...interface{}) *UserRelation {
+func (r *UserRelation) Where(cond interface{}, args ...interface{}) *UserRelation {
 	r.Relation.Where(cond, args...)
 	return r
 }
 
-func (r *UserRelation) And(cond string, args ...interface{}) *UserRelation {
+func (r *UserRelation) And(cond interface{}, args ...interface{}) *UserRelation {
 	r.Relation.And(cond, args...)
 	return r
 }
 
+func (r *UserRelation) Or(cond interface{}, args ...interface{}) *UserRelation {
+	r.Relation.Or(cond, args...)
+	return r
+}
+
+func (m User) Not(cond interface{}, args ...interface{}) *UserRelation {
+	return m.newRelation().Not(cond, args...)
+}
+
+func (r *UserRelation) Not(cond interface{}, args ...interface{}) *UserRelation {
+	r.Relation.Not(cond, args...)
+	return r
+}
+
+func (m User) WhereExists(sub interface{}) *UserRelation {
+	return m.newRelation().WhereExists(sub)
+}
+
+func (r *UserRelation) WhereExists(sub interface{}) *UserRelation {
+	r.Relation.WhereExists(sub)
+	return r
+}
+
+func (m User) WhereNotExists(sub interface{}) *UserRelation {
+	return m.newRelation().WhereNotExists(sub)
+}
+
+func (r *UserRelation) WhereNotExists(sub interface{}) *UserRelation {
+	r.Relation.WhereNotExists(sub)
+	return r
+}
+
+func (m User) From(source interface{}, alias string) *UserRelation {
+	return m.newRelation().From(source, alias)
+}
+
+func (r *UserRelation) From(source interface{}, alias string) *UserRelation {
+	r.Relation.From(source, alias)
+	return r
+}
+
 func (m User) Order(column, order string) *UserRelation {
 	return m.newRelation().Order(column, order)
 }
@@ -98,6 +208,51 @@ func (r *UserRelation) Order(column, order string) *UserRelation {
 	return r
 }
 
+func (m User) Unscoped() *UserRelation {
+	return m.unscopedRelationTx(nil)
+}
+
+func (r *UserRelation) Unscoped() *UserRelation {
+	r.Relation.Unscoped()
+	return r
+}
+
+func (m User) Unscope(clauses ...query.Clause) *UserRelation {
+	return m.newRelation().Unscope(clauses...)
+}
+
+func (r *UserRelation) Unscope(clauses ...query.Clause) *UserRelation {
+	r.Relation.Unscope(clauses...)
+	return r
+}
+
+func (m User) Rewhere(cond string, args ...interface{}) *UserRelation {
+	return m.newRelation().Rewhere(cond, args...)
+}
+
+func (r *UserRelation) Rewhere(cond string, args ...interface{}) *UserRelation {
+	r.Relation.Rewhere(cond, args...)
+	return r
+}
+
+func (m User) Reorder(column, order string) *UserRelation {
+	return m.newRelation().Reorder(column, order)
+}
+
+func (r *UserRelation) Reorder(column, order string) *UserRelation {
+	r.Relation.Reorder(column, order)
+	return r
+}
+
+func (m User) ReverseOrder() *UserRelation {
+	return m.newRelation().ReverseOrder()
+}
+
+func (r *UserRelation) ReverseOrder() *UserRelation {
+	r.Relation.ReverseOrder()
+	return r
+}
+
 func (m User) Limit(limit int) *UserRelation {
 	return m.newRelation().Limit(limit)
 }
@@ -130,9 +285,72 @@ func (r *UserRelation) Having(cond string, args ...interface{}) *UserRelation {
 	return r
 }
 
-func (m User) IsValid() (bool, *ar.Errors) {
+func (m User) Joins(sql string, args ...interface{}) *UserRelation {
+	return m.newRelation().Joins(sql, args...)
+}
+
+func (r *UserRelation) Joins(sql string, args ...interface{}) *UserRelation {
+	r.Relation.Joins(sql, args...)
+	return r
+}
+
+func (r *UserRelation) Union(query interface{}) *UserRelation {
+	r.Relation.Union(query)
+	return r
+}
+
+func (r *UserRelation) UnionAll(query interface{}) *UserRelation {
+	r.Relation.UnionAll(query)
+	return r
+}
+
+func (r *UserRelation) Intersect(query interface{}) *UserRelation {
+	r.Relation.Intersect(query)
+	return r
+}
+
+func (r *UserRelation) Except(query interface{}) *UserRelation {
+	r.Relation.Except(query)
+	return r
+}
+
+func (m User) Lock(mode query.LockMode) *UserRelation {
+	return m.newRelation().Lock(mode)
+}
+
+func (r *UserRelation) Lock(mode query.LockMode) *UserRelation {
+	r.Relation.Lock(mode)
+	return r
+}
+
+func (m *User) Reload(lock ...query.LockMode) error {
+	return m.ReloadContext(context.Background(), lock...)
+}
+
+func (m *User) ReloadTx(tx *ar.Tx, lock ...query.LockMode) error {
+	return m.reload(tx.Context(), tx, lock)
+}
+
+func (m *User) ReloadContext(ctx context.Context, lock ...query.LockMode) error {
+	return m.reload(ctx, nil, lock)
+}
+
+func (m *User) reload(ctx context.Context, tx *ar.Tx, lock []query.LockMode) error {
+	r := m.newRelationTx(tx).Where("id", m.Id)
+	if len(lock) > 0 {
+		r.Lock(lock[0])
+	}
+	if err := r.Relation.QueryRowContext(ctx, m.fieldPtrsByName(r.Relation.GetColumns())...); err != nil {
+		return err
+	}
+	m.takeSnapshot()
+	return nil
+}
+
+func (m *User) IsValid() (bool, *ar.Errors) {
 	result := true
 	errors := &ar.Errors{}
+
 	var on ar.On
 	if m.IsNewRecord() {
 		on = ar.OnCreate()
@@ -154,9 +372,69 @@ func (m User) IsValid() (bool, *ar.Errors) {
 	if len(errors.Messages) > 0 {
 		result = false
 	}
+
 	return result, errors
 }
 
+func (m *User) takeSnapshot(columns ...string) {
+	if len(columns) == 0 {
+		columns = m.columnNames()
+	}
+	values := map[string]interface{}{}
+	for _, c := range columns {
+		values[c] = m.fieldValueByName(c)
+	}
+	m.Snapshot.TakeSnapshot(values)
+}
+
+func (m *User) changedColumns() []string {
+	columns := []string{}
+	for _, c := range m.columnNames() {
+		if c == "id" {
+			continue
+		}
+		if _, changed := m.Snapshot.SnapshotChange(c, m.fieldValueByName(c)); !changed {
+			continue
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+func (m *User) Changed() bool {
+	return len(m.Changes()) > 0
+}
+
+func (m *User) Changes() map[string]ar.Change {
+	changes := map[string]ar.Change{}
+	for _, c := range m.columnNames() {
+		if change, changed := m.Snapshot.SnapshotChange(c, m.fieldValueByName(c)); changed {
+			changes[c] = change
+		}
+	}
+	return changes
+}
+
+func (m *User) WasChanged(column string) bool {
+	if !m.isColumnName(column) {
+		return false
+	}
+	_, changed := m.Snapshot.SnapshotChange(column, m.fieldValueByName(column))
+	return changed
+}
+
+func (m *User) RestoreAttributes() {
+	for _, c := range m.columnNames() {
+		m.Snapshot.RestoreSnapshot(c, m.fieldPtrByName(c))
+	}
+}
+
+func (m *User) setCreateTimestamps() {
+}
+
+func (m *User) setUpdateTimestamps() {
+}
+
 func (m User) OlderThan(args ...interface{}) *UserRelation {
 	r := m.newRelation()
 	m.scopeOlderThan(ar.Scope{r.Relation, args})
@@ -169,12 +447,24 @@ func (r *UserRelation) OlderThan(args ...interface{}) *UserRelation {
 }
 
 func (m *User) Posts() ([]*Post, error) {
+	return m.hasManyPostsRelation(nil).Query()
+}
+
+func (m *User) PostsTx(tx *ar.Tx) ([]*Post, error) {
+	return m.hasManyPostsRelation(tx).Query()
+}
+
+func (m *User) PostsContext(ctx context.Context) ([]*Post, error) {
+	return m.hasManyPostsRelation(nil).QueryContext(ctx)
+}
+
+func (m *User) hasManyPostsRelation(tx *ar.Tx) *PostRelation {
 	asc := m.hasManyPosts()
 	fk := "user_id"
 	if asc != nil && asc.ForeignKey != "" {
 		fk = asc.ForeignKey
 	}
-	return Post{}.Where(fk, m.Id).Query()
+	return Post{}.Tx(tx).Where(fk, m.Id)
 }
 
 func (m User) JoinsPosts() *UserRelation {
@@ -182,13 +472,26 @@ func (m User) JoinsPosts() *UserRelation {
 }
 
 func (r *UserRelation) JoinsPosts() *UserRelation {
+	r.Relation.InnerJoin("posts", r.hasManyPostsOn())
+	return r
+}
+
+func (m User) LeftJoinsPosts() *UserRelation {
+	return m.newRelation().LeftJoinsPosts()
+}
+
+func (r *UserRelation) LeftJoinsPosts() *UserRelation {
+	r.Relation.LeftJoin("posts", r.hasManyPostsOn())
+	return r
+}
+
+func (r *UserRelation) hasManyPostsOn() string {
 	asc := r.src.hasManyPosts()
 	fk := "user_id"
 	if asc != nil && asc.ForeignKey != "" {
 		fk = asc.ForeignKey
 	}
-	r.Relation.InnerJoin("posts", fmt.Sprintf("posts.%s = users.id", fk))
-	return r
+	return fmt.Sprintf("posts.%s = users.id", fk)
 }
 
 func (m *User) BuildPost(p PostParams) *Post {
@@ -207,11 +510,175 @@ func (m User) Build(p UserParams) *User {
 }
 
 func (m User) Create(p UserParams) (*User, *ar.Errors) {
+	return m.create(context.Background(), nil, p)
+}
+
+func (m User) CreateTx(tx *ar.Tx, p UserParams) (*User, *ar.Errors) {
+	return m.create(tx.Context(), tx, p)
+}
+
+func (m User) CreateContext(ctx context.Context, p UserParams) (*User, *ar.Errors) {
+	return m.create(ctx, nil, p)
+}
+
+func (m User) create(ctx context.Context, tx *ar.Tx, p UserParams) (*User, *ar.Errors) {
 	n := m.Build(p)
-	_, errs := n.Save()
+	_, errs := n.save(ctx, tx)
 	return n, errs
 }
 
+func (m User) Upsert(p UserParams, conflictColumns ...string) (*User, *ar.Errors) {
+	return m.upsert(context.Background(), nil, p, conflictColumns...)
+}
+
+func (m User) UpsertTx(tx *ar.Tx, p UserParams, conflictColumns ...string) (*User, *ar.Errors) {
+	return m.upsert(tx.Context(), tx, p, conflictColumns...)
+}
+
+func (m User) UpsertContext(ctx context.Context, p UserParams, conflictColumns ...string) (*User, *ar.Errors) {
+	return m.upsert(ctx, nil, p, conflictColumns...)
+}
+
+func (m User) upsert(ctx context.Context, tx *ar.Tx, p UserParams, conflictColumns ...string) (*User, *ar.Errors) {
+	n := m.Build(p)
+	if ok, errs := n.IsValid(); !ok {
+		return nil, errs
+	}
+	errs := &ar.Errors{}
+
+	n.setCreateTimestamps()
+	params := map[string]interface{}{
+		"name": n.Name,
+		"age":  n.Age,
+	}
+	rel := newRelation(tx).Table("users").Columns(n.columnNames()...)
+	for _, c := range conflictColumns {
+		if !n.isColumnName(c) {
+			errs.Add(c, "is not a column")
+			return nil, errs
+		}
+		v := n.fieldValueByName(c)
+		if _, ok := params[c]; !ok {
+			if ar.IsZero(v) {
+				errs.Add(c, "can't be blank")
+				return nil, errs
+			}
+			params[c] = v
+		}
+		rel.Where(c, v)
+	}
+	ins := newInsert(tx).Table("users").Params(params).OnConflict(conflictColumns...)
+	ins.DoUpdate()
+	if err := ins.ExecReturningContext(ctx, "id", &n.Id); err != nil {
+		errs.AddError("base", err)
+		return nil, errs
+	}
+	if len(conflictColumns) == 0 {
+		rel.Where("id", n.Id)
+	}
+	if err := rel.QueryRowContext(ctx, n.fieldPtrsByName(n.columnNames())...); err != nil {
+		errs.AddError("base", err)
+		return nil, errs
+	}
+	n.takeSnapshot()
+	return n, nil
+}
+
+func (m User) UpsertAll(ps []UserParams, conflictColumns ...string) ([]*User, *ar.Errors) {
+	return m.upsertAll(context.Background(), nil, ps, conflictColumns...)
+}
+
+func (m User) UpsertAllTx(tx *ar.Tx, ps []UserParams, conflictColumns ...string) ([]*User, *ar.Errors) {
+	return m.upsertAll(tx.Context(), tx, ps, conflictColumns...)
+}
+
+func (m User) UpsertAllContext(ctx context.Context, ps []UserParams, conflictColumns ...string) ([]*User, *ar.Errors) {
+	return m.upsertAll(ctx, nil, ps, conflictColumns...)
+}
+
+func (m User) upsertAll(ctx context.Context, tx *ar.Tx, ps []UserParams, conflictColumns ...string) ([]*User, *ar.Errors) {
+	var ns []*User
+	var errs *ar.Errors
+	fn := func(tx *ar.Tx) error {
+		for _, p := range ps {
+			n, e := m.upsert(ctx, tx, p, conflictColumns...)
+			if e != nil {
+				errs = e
+				return e
+			}
+			ns = append(ns, n)
+		}
+		return nil
+	}
+
+	var err error
+	if tx != nil {
+		err = tx.Transaction(fn)
+	} else {
+		err = TransactionContext(ctx, fn)
+	}
+	if err != nil {
+		if errs == nil {
+			errs = &ar.Errors{}
+			errs.AddError("base", err)
+		}
+		return nil, errs
+	}
+	return ns, nil
+}
+
+func (m User) InsertAll(ps []UserParams, validate ...bool) (int64, *ar.Errors) {
+	return m.insertAll(context.Background(), nil, ps, validate...)
+}
+
+func (m User) InsertAllTx(tx *ar.Tx, ps []UserParams, validate ...bool) (int64, *ar.Errors) {
+	return m.insertAll(tx.Context(), tx, ps, validate...)
+}
+
+func (m User) InsertAllContext(ctx context.Context, ps []UserParams, validate ...bool) (int64, *ar.Errors) {
+	return m.insertAll(ctx, nil, ps, validate...)
+}
+
+func (m User) insertAll(ctx context.Context, tx *ar.Tx, ps []UserParams, validate ...bool) (int64, *ar.Errors) {
+	if len(ps) == 0 {
+		return 0, nil
+	}
+	rows := make([]map[string]interface{}, len(ps))
+	for i, p := range ps {
+		n := m.Build(p)
+		n.setCreateTimestamps()
+		if len(validate) == 0 || len(validate) > 0 && validate[0] {
+			if ok, errs := n.IsValid(); !ok {
+				return 0, errs
+			}
+		}
+		rows[i] = map[string]interface{}{
+			"name": n.Name,
+			"age":  n.Age,
+		}
+	}
+
+	var affected int64
+	fn := func(tx *ar.Tx) error {
+		var err error
+		affected, err = newInsert(tx).Table("users").Rows(rows...).ExecAllContext(ctx)
+		return err
+	}
+
+	var err error
+	if tx != nil {
+		err = tx.Transaction(fn)
+	} else {
+		err = TransactionContext(ctx, fn)
+	}
+	if err != nil {
+		errs := &ar.Errors{}
+		errs.AddError("base", err)
+		return 0, errs
+	}
+	return affected, nil
+}
+
 func (m *User) IsNewRecord() bool {
 	return ar.IsZero(m.Id)
 }
@@ -221,43 +688,74 @@ func (m *User) IsPersistent() bool {
 }
 
 func (m *User) Save(validate ...bool) (bool, *ar.Errors) {
+	return m.save(context.Background(), nil, validate...)
+}
+
+func (m *User) SaveTx(tx *ar.Tx, validate ...bool) (bool, *ar.Errors) {
+	return m.save(tx.Context(), tx, validate...)
+}
+
+func (m *User) SaveContext(ctx context.Context, validate ...bool) (bool, *ar.Errors) {
+	return m.save(ctx, nil, validate...)
+}
+
+func (m *User) save(ctx context.Context, tx *ar.Tx, validate ...bool) (bool, *ar.Errors) {
 	if len(validate) == 0 || len(validate) > 0 && validate[0] {
 		if ok, errs := m.IsValid(); !ok {
 			return false, errs
 		}
 	}
 	errs := &ar.Errors{}
+
 	if m.IsNewRecord() {
-		ins := ar.NewInsert(db, logger).Table("users").Params(map[string]interface{}{
+
+		m.setCreateTimestamps()
+		ins := newInsert(tx).Table("users").Params(map[string]interface{}{
 			"name": m.Name,
 			"age":  m.Age,
 		})
 
-		if result, err := ins.Exec(); err != nil {
+		if err := ins.ExecReturningContext(ctx, "id", &m.Id); err != nil {
 			errs.AddError("base", err)
 			return false, errs
-		} else {
-			if lastId, err := result.LastInsertId(); err == nil {
-				m.Id = int(lastId)
-			}
 		}
-		return true, nil
+		m.takeSnapshot()
+
 	} else {
-		upd := ar.NewUpdate(db, logger).Table("users").Params(map[string]interface{}{
-			"id":   m.Id,
-			"name": m.Name,
-			"age":  m.Age,
-		}).Where("id", m.Id)
 
-		if _, err := upd.Exec(); err != nil {
-			errs.AddError("base", err)
-			return false, errs
+		if columns := m.changedColumns(); len(columns) > 0 {
+			params := map[string]interface{}{}
+			for _, c := range columns {
+				params[c] = m.fieldValueByName(c)
+			}
+
+			upd := newUpdate(tx).Table("users").Params(params).Where("id", m.Id)
+
+			if _, err := upd.ExecContext(ctx); err != nil {
+				errs.AddError("base", err)
+				return false, errs
+			}
+			m.takeSnapshot()
 		}
-		return true, nil
+
 	}
+
+	return true, nil
 }
 
 func (m *User) Update(p UserParams) (bool, *ar.Errors) {
+	return m.update(context.Background(), nil, p)
+}
+
+func (m *User) UpdateTx(tx *ar.Tx, p UserParams) (bool, *ar.Errors) {
+	return m.update(tx.Context(), tx, p)
+}
+
+func (m *User) UpdateContext(ctx context.Context, p UserParams) (bool, *ar.Errors) {
+	return m.update(ctx, nil, p)
+}
+
+func (m *User) update(ctx context.Context, tx *ar.Tx, p UserParams, validate ...bool) (bool, *ar.Errors) {
 
 	if !ar.IsZero(p.Id) {
 		m.Id = p.Id
@@ -268,47 +766,203 @@ func (m *User) Update(p UserParams) (bool, *ar.Errors) {
 	if !ar.IsZero(p.Age) {
 		m.Age = p.Age
 	}
-	return m.Save()
+	return m.save(ctx, tx, validate...)
 }
 
 func (m *User) UpdateColumns(p UserParams) (bool, *ar.Errors) {
+	return m.update(context.Background(), nil, p, false)
+}
 
-	if !ar.IsZero(p.Id) {
-		m.Id = p.Id
+func (m *User) UpdateColumnsTx(tx *ar.Tx, p UserParams) (bool, *ar.Errors) {
+	return m.update(tx.Context(), tx, p, false)
+}
+
+func (m *User) UpdateColumnsContext(ctx context.Context, p UserParams) (bool, *ar.Errors) {
+	return m.update(ctx, nil, p, false)
+}
+
+func (m User) UpdateAll(params interface{}) (int64, error) {
+	return m.newRelation().UpdateAll(params)
+}
+
+func (m User) UpdateAllContext(ctx context.Context, params interface{}) (int64, error) {
+	return m.newRelation().UpdateAllContext(ctx, params)
+}
+
+func (r *UserRelation) UpdateAll(params interface{}) (int64, error) {
+	return r.UpdateAllContext(context.Background(), params)
+}
+
+func (r *UserRelation) UpdateAllContext(ctx context.Context, params interface{}) (int64, error) {
+	var p map[string]interface{}
+	switch params := params.(type) {
+	case map[string]interface{}:
+		p = params
+	case UserParams:
+		p = map[string]interface{}{}
+		if !ar.IsZero(params.Id) {
+			p["id"] = params.Id
+		}
+		if !ar.IsZero(params.Name) {
+			p["name"] = params.Name
+		}
+		if !ar.IsZero(params.Age) {
+			p["age"] = params.Age
+		}
+	default:
+		return 0, fmt.Errorf("unsupported params type %T", params)
 	}
-	if !ar.IsZero(p.Name) {
-		m.Name = p.Name
+	if len(p) == 0 {
+		return 0, nil
 	}
-	if !ar.IsZero(p.Age) {
-		m.Age = p.Age
+
+	return r.Relation.UpdateAllContext(ctx, p)
+}
+
+func (m *User) Increment(column string, by int) (bool, *ar.Errors) {
+	return m.IncrementContext(context.Background(), column, by)
+}
+
+func (m *User) IncrementTx(tx *ar.Tx, column string, by int) (bool, *ar.Errors) {
+	return m.updateExpressions(tx.Context(), tx, map[string]interface{}{
+		column: query.Increment(column, by),
+	})
+}
+
+func (m *User) IncrementContext(ctx context.Context, column string, by int) (bool, *ar.Errors) {
+	return m.updateExpressions(ctx, nil, map[string]interface{}{
+		column: query.Increment(column, by),
+	})
+}
+
+func (m *User) Decrement(column string, by int) (bool, *ar.Errors) {
+	return m.IncrementContext(context.Background(), column, -by)
+}
+
+func (m *User) DecrementTx(tx *ar.Tx, column string, by int) (bool, *ar.Errors) {
+	return m.IncrementTx(tx, column, -by)
+}
+
+func (m *User) DecrementContext(ctx context.Context, column string, by int) (bool, *ar.Errors) {
+	return m.IncrementContext(ctx, column, -by)
+}
+
+func (m *User) Touch(columns ...string) (bool, *ar.Errors) {
+	return m.TouchContext(context.Background(), columns...)
+}
+
+func (m *User) TouchTx(tx *ar.Tx, columns ...string) (bool, *ar.Errors) {
+	return m.touch(tx.Context(), tx, columns)
+}
+
+func (m *User) TouchContext(ctx context.Context, columns ...string) (bool, *ar.Errors) {
+	return m.touch(ctx, nil, columns)
+}
+
+func (m *User) touch(ctx context.Context, tx *ar.Tx, columns []string) (bool, *ar.Errors) {
+	for _, c := range columns {
+		if !m.isTimeColumn(c) {
+			errs := &ar.Errors{}
+			errs.Add(c, "is not a timestamp")
+			return false, errs
+		}
 	}
-	return m.Save(false)
+	return m.updateExpressions(ctx, tx, m.touchParams(columns))
 }
 
-func (m *User) Destroy() (bool, *ar.Errors) {
-	return m.Delete()
+func (m *User) isTimeColumn(name string) bool {
+	switch name {
+	}
+	return false
 }
 
-func (m *User) Delete() (bool, *ar.Errors) {
+func (m *User) touchParams(columns []string) map[string]interface{} {
+	now := ar.Now()
+	params := map[string]interface{}{}
+	for _, c := range columns {
+		params[c] = now
+	}
+	return params
+}
+
+func (m *User) updateExpressions(ctx context.Context, tx *ar.Tx, params map[string]interface{}) (bool, *ar.Errors) {
 	errs := &ar.Errors{}
-	if _, err := ar.NewDelete(db, logger).Table("users").Where("id", m.Id).Exec(); err != nil {
+	if len(params) == 0 {
+		return true, nil
+	}
+	columns := []string{}
+	for c := range params {
+		if !m.isColumnName(c) {
+			errs.Add(c, "is not a column")
+			return false, errs
+		}
+		columns = append(columns, c)
+	}
+
+	upd := newUpdate(tx).Table("users").Params(params).Where("id", m.Id)
+	if _, err := upd.ExecContext(ctx); err != nil {
+		errs.AddError("base", err)
+		return false, errs
+	}
+
+	rel := newRelation(tx).Table("users").Columns(columns...).Where("id", m.Id)
+	if err := rel.QueryRowContext(ctx, m.fieldPtrsByName(columns)...); err != nil {
 		errs.AddError("base", err)
 		return false, errs
 	}
+	m.takeSnapshot(columns...)
 	return true, nil
 }
 
-func (m User) DeleteAll() (bool, *ar.Errors) {
+func (m *User) Destroy() (bool, *ar.Errors) {
+	return m.delete(context.Background(), nil, false)
+}
+
+func (m *User) DestroyTx(tx *ar.Tx) (bool, *ar.Errors) {
+	return m.delete(tx.Context(), tx, false)
+}
+
+func (m *User) DestroyContext(ctx context.Context) (bool, *ar.Errors) {
+	return m.delete(ctx, nil, false)
+}
+
+func (m *User) Delete() (bool, *ar.Errors) {
+	return m.delete(context.Background(), nil, false)
+}
+
+func (m *User) DeleteTx(tx *ar.Tx) (bool, *ar.Errors) {
+	return m.delete(tx.Context(), tx, false)
+}
+
+func (m *User) DeleteContext(ctx context.Context) (bool, *ar.Errors) {
+	return m.delete(ctx, nil, false)
+}
+
+func (m *User) delete(ctx context.Context, tx *ar.Tx, really bool) (bool, *ar.Errors) {
 	errs := &ar.Errors{}
-	if _, err := ar.NewDelete(db, logger).Table("users").Exec(); err != nil {
+
+	if _, err := newDelete(tx).Table("users").Where("id", m.Id).ExecContext(ctx); err != nil {
 		errs.AddError("base", err)
 		return false, errs
 	}
+
 	return true, nil
 }
 
+func (m User) DeleteAll() (int64, error) {
+	return m.newRelation().DeleteAll()
+}
+
+func (m User) DeleteAllContext(ctx context.Context) (int64, error) {
+	return m.newRelation().DeleteAllContext(ctx)
+}
+
 func (r *UserRelation) Query() ([]*User, error) {
-	rows, err := r.Relation.Query()
+	return r.QueryContext(context.Background())
+}
+
+func (r *UserRelation) QueryContext(ctx context.Context) ([]*User, error) {
+	rows, err := r.Relation.QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -321,17 +975,23 @@ func (r *UserRelation) Query() ([]*User, error) {
 		if err != nil {
 			return nil, err
 		}
+		row.takeSnapshot()
 		results = append(results, row)
 	}
 	return results, nil
 }
 
 func (r *UserRelation) QueryRow() (*User, error) {
+	return r.QueryRowContext(context.Background())
+}
+
+func (r *UserRelation) QueryRowContext(ctx context.Context) (*User, error) {
 	row := &User{}
-	err := r.Relation.QueryRow(row.fieldPtrsByName(r.Relation.GetColumns())...)
+	err := r.Relation.QueryRowContext(ctx, row.fieldPtrsByName(r.Relation.GetColumns())...)
 	if err != nil {
 		return nil, err
 	}
+	row.takeSnapshot()
 	return row, nil
 }
 
@@ -339,10 +999,18 @@ func (m User) Exists() bool {
 	return m.newRelation().Exists()
 }
 
+func (m User) ExistsContext(ctx context.Context) bool {
+	return m.newRelation().ExistsContext(ctx)
+}
+
 func (m User) Count(column ...string) int {
 	return m.newRelation().Count(column...)
 }
 
+func (m User) CountContext(ctx context.Context, column ...string) int {
+	return m.newRelation().CountContext(ctx, column...)
+}
+
 func (m User) All() *UserRelation {
 	return m.newRelation().All()
 }
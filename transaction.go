@@ -0,0 +1,137 @@
+package ar
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type Tx struct {
+	tx            *sql.Tx
+	ctx           context.Context
+	logger        *Logger
+	depth         int
+	savepoint     string
+	parent        *Tx
+	afterCommit   []func()
+	afterRollback []func()
+}
+
+func Transaction(db *sql.DB, logger *Logger, fn func(tx *Tx) error) error {
+	return TransactionContext(context.Background(), db, logger, fn)
+}
+
+func TransactionContext(ctx context.Context, db *sql.DB, logger *Logger, fn func(tx *Tx) error) error {
+	begin := time.Now()
+	t, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	tx := &Tx{tx: t, ctx: ctx, logger: logger}
+	tx.log(begin, "BEGIN;")
+	return tx.run(fn)
+}
+
+func (tx *Tx) Transaction(fn func(tx *Tx) error) error {
+	nested := &Tx{
+		tx:     tx.tx,
+		ctx:    tx.ctx,
+		logger: tx.logger,
+		depth:  tx.depth + 1,
+		parent: tx,
+	}
+	nested.savepoint = fmt.Sprintf("sp%d", nested.depth)
+	if _, err := nested.executer().ExecContext(nested.ctx, fmt.Sprintf("SAVEPOINT %s;", nested.savepoint)); err != nil {
+		return err
+	}
+	return nested.run(fn)
+}
+
+func (tx *Tx) AfterCommit(fn func()) {
+	tx.afterCommit = append(tx.afterCommit, fn)
+}
+
+func (tx *Tx) AfterRollback(fn func()) {
+	tx.afterRollback = append(tx.afterRollback, fn)
+}
+
+func (tx *Tx) Context() context.Context {
+	if tx == nil || tx.ctx == nil {
+		return context.Background()
+	}
+	return tx.ctx
+}
+
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return tx.tx.ExecContext(ctx, query, args...)
+}
+
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.tx.QueryContext(ctx, query, args...)
+}
+
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return tx.tx.QueryRowContext(ctx, query, args...)
+}
+
+func (tx *Tx) run(fn func(tx *Tx) error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			tx.rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		tx.rollback()
+		return err
+	}
+	return tx.commit()
+}
+
+func (tx *Tx) commit() error {
+	if tx.savepoint != "" {
+		if _, err := tx.executer().ExecContext(tx.ctx, fmt.Sprintf("RELEASE SAVEPOINT %s;", tx.savepoint)); err != nil {
+			tx.rollback()
+			return err
+		}
+		tx.parent.afterCommit = append(tx.parent.afterCommit, tx.afterCommit...)
+		tx.parent.afterRollback = append(tx.parent.afterRollback, tx.afterRollback...)
+		return nil
+	}
+	begin := time.Now()
+	if err := tx.tx.Commit(); err != nil {
+		tx.runCallbacks(tx.afterRollback)
+		return err
+	}
+	tx.log(begin, "COMMIT;")
+	tx.runCallbacks(tx.afterCommit)
+	return nil
+}
+
+func (tx *Tx) rollback() error {
+	defer tx.runCallbacks(tx.afterRollback)
+	if tx.savepoint != "" {
+		_, err := tx.executer().ExecContext(tx.ctx, fmt.Sprintf("ROLLBACK TO SAVEPOINT %s;", tx.savepoint))
+		return err
+	}
+	defer tx.log(time.Now(), "ROLLBACK;")
+	return tx.tx.Rollback()
+}
+
+func (tx *Tx) runCallbacks(callbacks []func()) {
+	tx.afterCommit = nil
+	tx.afterRollback = nil
+	for _, fn := range callbacks {
+		fn()
+	}
+}
+
+func (tx *Tx) executer() *Executer {
+	return &Executer{conn: tx, logger: tx.logger}
+}
+
+func (tx *Tx) log(t time.Time, sql string) {
+	tx.logger.Print(time.Now().Sub(t), sql, []interface{}{})
+}
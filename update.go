@@ -1,6 +1,7 @@
 package ar
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/monochromegane/argen/query"
@@ -12,32 +13,84 @@ type Update struct {
 }
 
 func NewUpdate(db *sql.DB, logger *Logger) *Update {
+	return newUpdate(db, logger)
+}
+
+func NewUpdateTx(tx *Tx, logger *Logger) *Update {
+	return newUpdate(tx, logger)
+}
+
+func newUpdate(conn Conn, logger *Logger) *Update {
 	return &Update{
 		Update: &query.Update{},
-		exec:   &Executer{db, logger},
+		exec:   &Executer{conn: conn, logger: logger},
 	}
 }
 
+func (u *Update) Dialect(d query.Dialect) *Update {
+	u.Update.Dialect(d)
+	return u
+}
+
+func (u *Update) Cache(c *StmtCache) *Update {
+	u.exec.cache = c
+	return u
+}
+
 func (u *Update) Table(table string) *Update {
 	u.Update.Table(table)
 	return u
 }
 
-func (u *Update) Where(cond string, args ...interface{}) *Update {
+func (u *Update) Where(cond interface{}, args ...interface{}) *Update {
 	u.Update.Where(cond, args...)
 	return u
 }
 
-func (u *Update) And(cond string, args ...interface{}) *Update {
+func (u *Update) And(cond interface{}, args ...interface{}) *Update {
 	return u.Where(cond, args...)
 }
 
+func (u *Update) Or(cond interface{}, args ...interface{}) *Update {
+	u.Update.Or(cond, args...)
+	return u
+}
+
+func (u *Update) Not(cond interface{}, args ...interface{}) *Update {
+	u.Update.Not(cond, args...)
+	return u
+}
+
 func (u *Update) Params(params map[string]interface{}) *Update {
 	u.Update.Params(params)
 	return u
 }
 
+func (u *Update) Returning(columns ...string) *Update {
+	u.Update.Returning(columns...)
+	return u
+}
+
 func (u *Update) Exec() (sql.Result, error) {
+	return u.ExecContext(context.Background())
+}
+
+func (u *Update) ExecContext(ctx context.Context) (sql.Result, error) {
+	if err := u.ConditionError(); err != nil {
+		return nil, err
+	}
+	q, b := u.Update.Build()
+	return u.exec.ExecContext(ctx, q, b...)
+}
+
+func (u *Update) Query() (*sql.Rows, error) {
+	return u.QueryContext(context.Background())
+}
+
+func (u *Update) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	if err := u.ConditionError(); err != nil {
+		return nil, err
+	}
 	q, b := u.Update.Build()
-	return u.exec.Exec(q, b...)
+	return u.exec.QueryContext(ctx, q, b...)
 }